@@ -0,0 +1,1201 @@
+package iter
+
+// Enumerated[T] represents an element paired with its position.
+type Enumerated[T any] struct {
+	Index int
+	Value T
+}
+
+// Group[K, T] represents a group of elements sharing the same key.
+type Group[K, T any] struct {
+	Key    K
+	Values []T
+}
+
+type enumerateIter[T any] struct {
+	inner Iterator[T]
+	index int
+}
+
+// Enumerate returns an Iterator adapter that pairs each element of the
+// underlying Iterator with its index starting from zero.
+func Enumerate[T any](it Iterator[T]) Iterator[Enumerated[T]] {
+	return EnumerateFrom(it, 0)
+}
+
+// EnumerateFrom returns an Iterator adapter that pairs each element of the
+// underlying Iterator with its index starting from start.
+func EnumerateFrom[T any](it Iterator[T], start int) Iterator[Enumerated[T]] {
+	return &enumerateIter[T]{
+		inner: it,
+		index: start,
+	}
+}
+
+func (it *enumerateIter[T]) Next() Option[Enumerated[T]] {
+	v := it.inner.Next()
+	if v.IsNone() {
+		return None[Enumerated[T]]()
+	}
+	ret := Enumerated[T]{Index: it.index, Value: v.Unwrap()}
+	it.index++
+	return Some(ret)
+}
+
+func (it *enumerateIter[T]) size() Option[uint] {
+	return Len(it.inner)
+}
+
+type stepByIter[T any] struct {
+	inner Iterator[T]
+	step  uint
+	first bool
+}
+
+// StepBy returns an Iterator adapter that yields the first element of the
+// underlying Iterator and then every nth element after it. Panics if n is zero.
+func StepBy[T any](it Iterator[T], n uint) Iterator[T] {
+	if n == 0 {
+		panic("StepBy requires a step greater than zero.")
+	}
+	return &stepByIter[T]{
+		inner: it,
+		step:  n,
+		first: true,
+	}
+}
+
+func (it *stepByIter[T]) Next() Option[T] {
+	if it.first {
+		it.first = false
+		return it.inner.Next()
+	}
+	return Nth(it.inner, it.step-1)
+}
+
+type chunksIter[T any] struct {
+	inner Iterator[T]
+	size  uint
+}
+
+// Chunks returns an Iterator adapter that yields the elements of the underlying
+// Iterator in slices of size elements. The last slice may be shorter if the
+// underlying Iterator runs out. Each yielded slice is newly allocated. Panics if
+// size is zero.
+func Chunks[T any](it Iterator[T], size uint) Iterator[[]T] {
+	if size == 0 {
+		panic("Chunks requires a size greater than zero.")
+	}
+	return &chunksIter[T]{
+		inner: it,
+		size:  size,
+	}
+}
+
+func (it *chunksIter[T]) Next() Option[[]T] {
+	chunk := AppendTo(Take(it.inner, it.size), make([]T, 0, it.size))
+	if len(chunk) == 0 {
+		return None[[]T]()
+	}
+	return Some(chunk)
+}
+
+// ChunksExactIterator[T] is an Iterator yielding chunks of equal size that
+// keeps the trailing partial chunk aside.
+type ChunksExactIterator[T any] interface {
+	Iterator[[]T]
+	// Remainder returns the elements left over after the last full chunk. It
+	// is empty until the Iterator has been exhausted.
+	Remainder() []T
+}
+
+type chunksExactIter[T any] struct {
+	chunks    Iterator[[]T]
+	size      uint
+	remainder []T
+}
+
+// ChunksExact returns an Iterator adapter that yields the elements of the
+// underlying Iterator in slices of exactly size elements. A trailing partial
+// chunk is not yielded but is available through Remainder. Each yielded slice
+// is newly allocated. Panics if size is zero.
+func ChunksExact[T any](it Iterator[T], size uint) ChunksExactIterator[T] {
+	return &chunksExactIter[T]{
+		chunks:    Chunks(it, size),
+		size:      size,
+		remainder: []T{},
+	}
+}
+
+func (it *chunksExactIter[T]) Next() Option[[]T] {
+	v := it.chunks.Next()
+	if v.IsSome() && uint(len(v.Unwrap())) < it.size {
+		it.remainder = v.Unwrap()
+		return None[[]T]()
+	}
+	return v
+}
+
+func (it *chunksExactIter[T]) Remainder() []T {
+	return it.remainder
+}
+
+type windowsIter[T any] struct {
+	inner Iterator[T]
+	buf   []T
+	step  uint
+	start int
+	full  bool
+	done  bool
+}
+
+// Windows returns an Iterator adapter that yields overlapping windows of size
+// consecutive elements of the underlying Iterator, advancing by one element at
+// a time. Nothing is yielded if the underlying Iterator has fewer than size
+// elements. Each yielded slice is newly allocated. Panics if size is zero.
+func Windows[T any](it Iterator[T], size uint) Iterator[[]T] {
+	if size == 0 {
+		panic("Windows requires a size greater than zero.")
+	}
+	return WindowsStep(it, size, 1)
+}
+
+// WindowsStep returns an Iterator adapter that yields windows of size
+// consecutive elements of the underlying Iterator, with each window starting
+// step elements after the start of the previous one. If step is greater than
+// size, the elements between windows are skipped. Only full windows are
+// yielded, so a step equal to size behaves like ChunksExact. Each yielded slice
+// is newly allocated. Panics if size or step is zero.
+func WindowsStep[T any](it Iterator[T], size, step uint) Iterator[[]T] {
+	if size == 0 {
+		panic("WindowsStep requires a size greater than zero.")
+	}
+	if step == 0 {
+		panic("WindowsStep requires a step greater than zero.")
+	}
+	return &windowsIter[T]{
+		inner: it,
+		buf:   make([]T, 0, size),
+		step:  step,
+	}
+}
+
+func (it *windowsIter[T]) Next() Option[[]T] {
+	if it.done {
+		return None[[]T]()
+	}
+	if it.full && it.step < uint(len(it.buf)) {
+		for i := uint(0); i < it.step; i++ {
+			v := it.inner.Next()
+			if v.IsNone() {
+				it.done = true
+				return None[[]T]()
+			}
+			it.buf[it.start] = v.Unwrap()
+			it.start = (it.start + 1) % len(it.buf)
+		}
+	} else {
+		if it.full {
+			skip := it.step - uint(len(it.buf))
+			if skip > 0 && Nth(it.inner, skip-1).IsNone() {
+				it.done = true
+				return None[[]T]()
+			}
+			it.buf = it.buf[:0]
+			it.start = 0
+		}
+		it.buf = AppendTo(Take(it.inner, uint(cap(it.buf))), it.buf)
+		if len(it.buf) < cap(it.buf) {
+			it.done = true
+			return None[[]T]()
+		}
+		it.full = true
+	}
+	window := make([]T, len(it.buf))
+	n := copy(window, it.buf[it.start:])
+	copy(window[n:], it.buf[:it.start])
+	return Some(window)
+}
+
+type scanIter[T, S any] struct {
+	inner Iterator[T]
+	state S
+	fn    func(S, T) S
+}
+
+// Scan returns an Iterator adapter that threads an accumulator through the
+// elements of the underlying Iterator like Fold, yielding the accumulator after
+// each element has been applied. The initial accumulator is not yielded.
+func Scan[T, S any](it Iterator[T], init S, fn func(S, T) S) Iterator[S] {
+	return &scanIter[T, S]{
+		inner: it,
+		state: init,
+		fn:    fn,
+	}
+}
+
+func (it *scanIter[T, S]) Next() Option[S] {
+	v := it.inner.Next()
+	if v.IsNone() {
+		return None[S]()
+	}
+	it.state = it.fn(it.state, v.Unwrap())
+	return Some(it.state)
+}
+
+func (it *scanIter[T, S]) size() Option[uint] {
+	return Len(it.inner)
+}
+
+// PeekableIterator[T] is an Iterator that is able to look at its next element
+// without consuming it.
+type PeekableIterator[T any] interface {
+	Iterator[T]
+	// Peek returns the element that the next call to Next will yield without
+	// advancing the Iterator.
+	Peek() Option[T]
+}
+
+type peekableIter[T any] struct {
+	inner  Iterator[T]
+	peeked Option[T]
+	ok     bool
+}
+
+// Peekable returns an Iterator adapter that supports looking at the next element
+// of the underlying Iterator without consuming it. If it is already a
+// PeekableIterator it is returned as is.
+func Peekable[T any](it Iterator[T]) PeekableIterator[T] {
+	if p, ok := it.(PeekableIterator[T]); ok {
+		return p
+	}
+	return &peekableIter[T]{inner: it}
+}
+
+func (it *peekableIter[T]) Next() Option[T] {
+	if it.ok {
+		it.ok = false
+		return it.peeked
+	}
+	return it.inner.Next()
+}
+
+func (it *peekableIter[T]) Peek() Option[T] {
+	if !it.ok {
+		it.peeked = it.inner.Next()
+		it.ok = true
+	}
+	return it.peeked
+}
+
+func (it *peekableIter[T]) size() Option[uint] {
+	if !it.ok {
+		return Len(it.inner)
+	}
+	if it.peeked.IsNone() {
+		return Some[uint](0)
+	}
+	return MapOption(Len(it.inner), func(n uint) uint {
+		return n + 1
+	})
+}
+
+type takeWhilePeekIter[T any] struct {
+	inner PeekableIterator[T]
+	pred  func(T) bool
+	done  bool
+}
+
+// TakeWhilePeek returns an Iterator adapter that yields values from the
+// underlying PeekableIterator as long as pred predicate function returns true.
+// Unlike TakeWhile, the first element for which pred returns false is not
+// consumed and remains available from the underlying PeekableIterator.
+func TakeWhilePeek[T any](it PeekableIterator[T], pred func(T) bool) Iterator[T] {
+	return &takeWhilePeekIter[T]{
+		inner: it,
+		pred:  pred,
+	}
+}
+
+func (it *takeWhilePeekIter[T]) Next() Option[T] {
+	if it.done {
+		return None[T]()
+	}
+	v := it.inner.Peek()
+	if v.IsNone() || !it.pred(v.Unwrap()) {
+		it.done = true
+		return None[T]()
+	}
+	return it.inner.Next()
+}
+
+type cycleIter[T any] struct {
+	inner   Iterator[T]
+	buf     []T
+	pos     int
+	replay  bool
+	forever bool
+	passes  uint
+}
+
+// Cycle returns an Iterator adapter that yields the elements of the underlying
+// Iterator and then repeats them forever. The elements are buffered during the
+// first pass. If the underlying Iterator is empty, the returned Iterator is
+// empty too.
+func Cycle[T any](it Iterator[T]) Iterator[T] {
+	return &cycleIter[T]{
+		inner:   it,
+		forever: true,
+	}
+}
+
+// CycleN returns an Iterator adapter that yields the elements of the underlying
+// Iterator n times in total. The elements are buffered during the first pass. If
+// n is zero, the underlying Iterator is not consumed at all.
+func CycleN[T any](it Iterator[T], n uint) Iterator[T] {
+	switch n {
+	case 0:
+		return Empty[T]()
+	case 1:
+		return it
+	}
+	return &cycleIter[T]{
+		inner:  it,
+		passes: n - 1,
+	}
+}
+
+func (it *cycleIter[T]) Next() Option[T] {
+	if !it.replay {
+		v := it.inner.Next()
+		if v.IsSome() {
+			it.buf = append(it.buf, v.Unwrap())
+			return v
+		}
+		it.replay = true
+		it.inner = nil
+	}
+	if len(it.buf) == 0 {
+		return None[T]()
+	}
+	if it.pos == 0 && !it.forever {
+		if it.passes == 0 {
+			return None[T]()
+		}
+		it.passes--
+	}
+	v := it.buf[it.pos]
+	it.pos = (it.pos + 1) % len(it.buf)
+	return Some(v)
+}
+
+type dedupIter[T any] struct {
+	inner   Iterator[T]
+	same    func(T, T) bool
+	prev    T
+	started bool
+}
+
+// Dedup returns an Iterator adapter that collapses runs of consecutive equal
+// elements of the underlying Iterator into a single element. Elements that are
+// equal but not adjacent are all yielded.
+func Dedup[T comparable](it Iterator[T]) Iterator[T] {
+	return DedupBy(it, func(prev, cur T) bool {
+		return prev == cur
+	})
+}
+
+// DedupBy returns an Iterator adapter that collapses runs of consecutive
+// elements of the underlying Iterator for which function same returns true into
+// the first element of the run. Each element is compared against the last
+// yielded element.
+func DedupBy[T any](it Iterator[T], same func(prev, cur T) bool) Iterator[T] {
+	return &dedupIter[T]{
+		inner: it,
+		same:  same,
+	}
+}
+
+func (it *dedupIter[T]) Next() Option[T] {
+	for {
+		v := it.inner.Next()
+		if v.IsNone() {
+			return v
+		}
+		cur := v.Unwrap()
+		if !it.started || !it.same(it.prev, cur) {
+			it.started = true
+			it.prev = cur
+			return v
+		}
+	}
+}
+
+// Unique returns an Iterator adapter that yields each distinct element of the
+// underlying Iterator the first time it is seen. The returned Iterator keeps
+// track of every distinct element yielded so far, so its memory use grows with
+// the number of distinct elements.
+func Unique[T comparable](it Iterator[T]) Iterator[T] {
+	return UniqueBy(it, func(v T) T {
+		return v
+	})
+}
+
+// UniqueBy returns an Iterator adapter that yields the elements of the
+// underlying Iterator whose key, as returned by function key, has not been seen
+// before. The first element with each key is yielded and later ones are
+// dropped. The returned Iterator keeps track of every distinct key seen so far.
+func UniqueBy[T any, K comparable](it Iterator[T], key func(T) K) Iterator[T] {
+	seen := make(map[K]struct{})
+	return filterStateful(it, func(v T) bool {
+		k := key(v)
+		if _, ok := seen[k]; ok {
+			return false
+		}
+		seen[k] = struct{}{}
+		return true
+	})
+}
+
+// Inspect returns an Iterator adapter that calls function fn with each element
+// of the underlying Iterator as it passes through. The elements are yielded
+// unchanged.
+func Inspect[T any](it Iterator[T], fn func(T)) Iterator[T] {
+	return mapEffectful(it, func(v T) T {
+		fn(v)
+		return v
+	})
+}
+
+type intersperseIter[T any] struct {
+	inner   PeekableIterator[T]
+	sep     func() T
+	pending bool
+}
+
+// Intersperse returns an Iterator adapter that yields sep between each pair of
+// adjacent elements of the underlying Iterator.
+func Intersperse[T any](it Iterator[T], sep T) Iterator[T] {
+	return IntersperseWith(it, func() T {
+		return sep
+	})
+}
+
+// IntersperseWith returns an Iterator adapter that yields a separator returned by
+// function sep between each pair of adjacent elements of the underlying
+// Iterator. Function sep is called only when a separator is yielded.
+func IntersperseWith[T any](it Iterator[T], sep func() T) Iterator[T] {
+	return &intersperseIter[T]{
+		inner: Peekable(it),
+		sep:   sep,
+	}
+}
+
+func (it *intersperseIter[T]) Next() Option[T] {
+	if it.pending {
+		it.pending = false
+		if it.inner.Peek().IsSome() {
+			return Some(it.sep())
+		}
+	}
+	v := it.inner.Next()
+	it.pending = v.IsSome()
+	return v
+}
+
+// Interleave returns an Iterator adapter that alternates between yielding
+// elements of first and second, starting with first. Unlike Zip, once either of
+// the Iterators runs out the remaining elements of the other are yielded in
+// order.
+func Interleave[T any](first, second Iterator[T]) Iterator[T] {
+	return RoundRobin(first, second)
+}
+
+type roundRobinIter[T any] struct {
+	its []Iterator[T]
+	i   int
+}
+
+// RoundRobin returns an Iterator adapter that cycles through the given Iterators
+// yielding one element from each in turn. Iterators that run out are skipped
+// and never polled again. The returned Iterator ends once all of the Iterators
+// have run out.
+func RoundRobin[T any](its ...Iterator[T]) Iterator[T] {
+	return &roundRobinIter[T]{
+		its: append([]Iterator[T](nil), its...),
+	}
+}
+
+func (it *roundRobinIter[T]) Next() Option[T] {
+	for len(it.its) > 0 {
+		if v := it.its[it.i].Next(); v.IsSome() {
+			it.i = (it.i + 1) % len(it.its)
+			return v
+		}
+		it.its = append(it.its[:it.i], it.its[it.i+1:]...)
+		if it.i == len(it.its) {
+			it.i = 0
+		}
+	}
+	return None[T]()
+}
+
+type mapWhileIter[T, R any] struct {
+	inner Iterator[T]
+	fn    func(T) Option[R]
+	done  bool
+}
+
+// MapWhile returns an Iterator adapter that applies function fn to the elements
+// of the underlying Iterator and yields the results as long as fn returns a
+// value. Once fn returns None the returned Iterator ends without pulling any
+// more elements from the underlying Iterator.
+func MapWhile[T, R any](it Iterator[T], fn func(T) Option[R]) Iterator[R] {
+	return &mapWhileIter[T, R]{
+		inner: it,
+		fn:    fn,
+	}
+}
+
+func (it *mapWhileIter[T, R]) Next() Option[R] {
+	if it.done {
+		return None[R]()
+	}
+	v := it.inner.Next()
+	if v.IsNone() {
+		it.done = true
+		return None[R]()
+	}
+	r := it.fn(v.Unwrap())
+	it.done = r.IsNone()
+	return r
+}
+
+type batchingIter[T, B any] struct {
+	inner PeekableIterator[T]
+	fn    func(PeekableIterator[T]) Option[B]
+	done  bool
+}
+
+// Batching returns an Iterator adapter that yields batches built by function fn.
+// Function fn is called with the same PeekableIterator wrapping the underlying
+// Iterator each time a batch is requested, and it consumes as many elements as
+// it needs. The returned Iterator ends once fn returns None.
+func Batching[T, B any](it Iterator[T], fn func(PeekableIterator[T]) Option[B]) Iterator[B] {
+	return &batchingIter[T, B]{
+		inner: Peekable(it),
+		fn:    fn,
+	}
+}
+
+func (it *batchingIter[T, B]) Next() Option[B] {
+	if it.done {
+		return None[B]()
+	}
+	v := it.fn(it.inner)
+	it.done = v.IsNone()
+	return v
+}
+
+type coalesceIter[T any] struct {
+	inner   Iterator[T]
+	merge   func(T, T) Option[T]
+	pending Option[T]
+	started bool
+}
+
+// Coalesce returns an Iterator adapter that merges adjacent elements of the
+// underlying Iterator using function merge. If merge returns a value, it
+// replaces both elements and is merged with the next element in turn. If merge
+// returns None, prev is yielded and cur is kept for merging with the elements
+// after it.
+func Coalesce[T any](it Iterator[T], merge func(prev, cur T) Option[T]) Iterator[T] {
+	return &coalesceIter[T]{
+		inner: it,
+		merge: merge,
+	}
+}
+
+func (it *coalesceIter[T]) Next() Option[T] {
+	if !it.started {
+		it.started = true
+		it.pending = it.inner.Next()
+	}
+	if it.pending.IsNone() {
+		return it.pending
+	}
+	prev := it.pending.Unwrap()
+	for {
+		v := it.inner.Next()
+		if v.IsNone() {
+			it.pending = v
+			return Some(prev)
+		}
+		merged := it.merge(prev, v.Unwrap())
+		if merged.IsNone() {
+			it.pending = v
+			return Some(prev)
+		}
+		prev = merged.Unwrap()
+	}
+}
+
+type pairwiseIter[T any] struct {
+	inner   Iterator[T]
+	prev    T
+	started bool
+}
+
+// Pairwise returns an Iterator adapter that yields each element of the
+// underlying Iterator paired with the element following it. This is like
+// Windows with a size of two but does not allocate a slice for each pair.
+func Pairwise[T any](it Iterator[T]) Iterator[Pair[T, T]] {
+	return &pairwiseIter[T]{inner: it}
+}
+
+func (it *pairwiseIter[T]) Next() Option[Pair[T, T]] {
+	if !it.started {
+		it.started = true
+		v := it.inner.Next()
+		if v.IsNone() {
+			return None[Pair[T, T]]()
+		}
+		it.prev = v.Unwrap()
+	}
+	v := it.inner.Next()
+	if v.IsNone() {
+		return None[Pair[T, T]]()
+	}
+	pair := Pair[T, T]{it.prev, v.Unwrap()}
+	it.prev = pair.Second
+	return Some(pair)
+}
+
+type cartesianProductIter[A, B any] struct {
+	a        Iterator[A]
+	b        Iterator[B]
+	template Iterator[B]
+	buf      []B
+	cur      A
+	i        int
+	state    int
+}
+
+const (
+	productStart = iota
+	productFirstPass
+	productReplay
+	productCloned
+	productDone
+)
+
+// CartesianProduct returns an Iterator adapter that yields every element of a
+// paired with every element of b. All of the pairs for an element of a are
+// yielded before any pair for the next one. Unless b can be cloned, the elements
+// of b are buffered during the first pass so that they can be replayed for the
+// following elements of a. When b is cloned, the functions of adapters such as
+// Map and Filter in b are called again for every replay. Either way, b must be
+// finite while a may be infinite.
+func CartesianProduct[A, B any](a Iterator[A], b Iterator[B]) Iterator[Pair[A, B]] {
+	it := &cartesianProductIter[A, B]{
+		a: a,
+		b: b,
+	}
+	if Clone(b).IsSome() {
+		it.template = b
+	}
+	return it
+}
+
+func (it *cartesianProductIter[A, B]) advance() bool {
+	v := it.a.Next()
+	if v.IsNone() {
+		it.state = productDone
+		return false
+	}
+	it.cur = v.Unwrap()
+	it.i = 0
+	return true
+}
+
+func (it *cartesianProductIter[A, B]) Next() Option[Pair[A, B]] {
+	switch it.state {
+	case productStart:
+		if !it.advance() {
+			return None[Pair[A, B]]()
+		}
+		if it.template != nil {
+			it.b = Clone(it.template).Unwrap()
+			it.state = productCloned
+			return it.Next()
+		}
+		it.state = productFirstPass
+		fallthrough
+	case productFirstPass:
+		if v := it.b.Next(); v.IsSome() {
+			it.buf = append(it.buf, v.Unwrap())
+			return Some(Pair[A, B]{it.cur, v.Unwrap()})
+		}
+		it.b = nil
+		if len(it.buf) == 0 {
+			it.state = productDone
+			return None[Pair[A, B]]()
+		}
+		it.state = productReplay
+		it.i = len(it.buf)
+		fallthrough
+	case productReplay:
+		if it.i == len(it.buf) && !it.advance() {
+			return None[Pair[A, B]]()
+		}
+		v := it.buf[it.i]
+		it.i++
+		return Some(Pair[A, B]{it.cur, v})
+	case productCloned:
+		for {
+			if v := it.b.Next(); v.IsSome() {
+				it.i++
+				return Some(Pair[A, B]{it.cur, v.Unwrap()})
+			}
+			if it.i == 0 {
+				// b is empty.
+				it.state = productDone
+				return None[Pair[A, B]]()
+			}
+			if !it.advance() {
+				return None[Pair[A, B]]()
+			}
+			it.b = Clone(it.template).Unwrap()
+		}
+	}
+	return None[Pair[A, B]]()
+}
+
+type combinationsIter[T any] struct {
+	inner   Iterator[T]
+	pool    []T
+	indices []int
+	started bool
+	done    bool
+}
+
+// Combinations returns an Iterator adapter that yields every combination of k
+// elements of the underlying Iterator. The combinations are yielded in
+// lexicographic order of the element positions. The underlying Iterator is
+// collected into a slice on the first call to Next. If k is zero, a single empty
+// combination is yielded. Each yielded slice is newly allocated.
+func Combinations[T any](it Iterator[T], k uint) Iterator[[]T] {
+	return &combinationsIter[T]{
+		inner:   it,
+		indices: make([]int, k),
+	}
+}
+
+func (it *combinationsIter[T]) Next() Option[[]T] {
+	if it.done {
+		return None[[]T]()
+	}
+	k := len(it.indices)
+	if !it.started {
+		it.started = true
+		it.pool = ToSlice(it.inner)
+		it.inner = nil
+		if k > len(it.pool) {
+			it.done = true
+			return None[[]T]()
+		}
+		for i := range it.indices {
+			it.indices[i] = i
+		}
+	} else {
+		// Find the rightmost index that can still be moved forward.
+		i := k - 1
+		for i >= 0 && it.indices[i] == i+len(it.pool)-k {
+			i--
+		}
+		if i < 0 {
+			it.done = true
+			return None[[]T]()
+		}
+		it.indices[i]++
+		for j := i + 1; j < k; j++ {
+			it.indices[j] = it.indices[j-1] + 1
+		}
+	}
+	combination := make([]T, k)
+	for i, index := range it.indices {
+		combination[i] = it.pool[index]
+	}
+	return Some(combination)
+}
+
+type groupAdjacentIter[T any, K comparable] struct {
+	inner   Iterator[T]
+	key     func(T) K
+	next    Option[T]
+	nextKey K
+	started bool
+}
+
+// GroupAdjacentBy returns an Iterator adapter that groups runs of consecutive
+// elements of the underlying Iterator that share the same key, as returned by
+// function key. A new group is started whenever the key changes, so a key may
+// appear in multiple groups. Each yielded slice is newly allocated.
+func GroupAdjacentBy[T any, K comparable](it Iterator[T], key func(T) K) Iterator[Group[K, T]] {
+	return &groupAdjacentIter[T, K]{
+		inner: it,
+		key:   key,
+	}
+}
+
+func (it *groupAdjacentIter[T, K]) advance() {
+	it.next = it.inner.Next()
+	if it.next.IsSome() {
+		it.nextKey = it.key(it.next.Unwrap())
+	}
+}
+
+func (it *groupAdjacentIter[T, K]) Next() Option[Group[K, T]] {
+	if !it.started {
+		it.started = true
+		it.advance()
+	}
+	if it.next.IsNone() {
+		return None[Group[K, T]]()
+	}
+	group := Group[K, T]{Key: it.nextKey}
+	for it.next.IsSome() && it.nextKey == group.Key {
+		group.Values = append(group.Values, it.next.Unwrap())
+		it.advance()
+	}
+	return Some(group)
+}
+
+type splitByIter[T any] struct {
+	inner Iterator[T]
+	isSep func(T) bool
+	done  bool
+}
+
+// SplitBy returns an Iterator adapter that splits the underlying Iterator into
+// slices of the elements between separators, as determined by function isSep.
+// The separators are discarded. Like strings.Split, leading, trailing and
+// consecutive separators result in empty slices, and an empty Iterator yields a
+// single empty slice. Each yielded slice is newly allocated.
+func SplitBy[T any](it Iterator[T], isSep func(T) bool) Iterator[[]T] {
+	return &splitByIter[T]{
+		inner: it,
+		isSep: isSep,
+	}
+}
+
+func (it *splitByIter[T]) Next() Option[[]T] {
+	if it.done {
+		return None[[]T]()
+	}
+	group := []T{}
+	for {
+		v := it.inner.Next()
+		if v.IsNone() {
+			it.done = true
+			return Some(group)
+		}
+		if it.isSep(v.Unwrap()) {
+			return Some(group)
+		}
+		group = append(group, v.Unwrap())
+	}
+}
+
+type padToIter[T any] struct {
+	inner Iterator[T]
+	n     uint
+	fill  T
+	count uint
+}
+
+// PadTo returns an Iterator adapter that yields the elements of the underlying
+// Iterator followed by fill as many times as needed for the total number of
+// elements to reach n. Iterators with n or more elements are passed through
+// unchanged.
+func PadTo[T any](it Iterator[T], n uint, fill T) Iterator[T] {
+	return &padToIter[T]{
+		inner: it,
+		n:     n,
+		fill:  fill,
+	}
+}
+
+func (it *padToIter[T]) Next() Option[T] {
+	if it.inner != nil {
+		if v := it.inner.Next(); v.IsSome() {
+			it.count++
+			return v
+		}
+		it.inner = nil
+	}
+	if it.count >= it.n {
+		return None[T]()
+	}
+	it.count++
+	return Some(it.fill)
+}
+
+func (it *padToIter[T]) size() Option[uint] {
+	var padding uint
+	if it.count < it.n {
+		padding = it.n - it.count
+	}
+	if it.inner == nil {
+		return Some(padding)
+	}
+	return MapOption(Len(it.inner), func(n uint) uint {
+		if n > padding {
+			return n
+		}
+		return padding
+	})
+}
+
+// TakeEvery returns an Iterator adapter that skips the first offset elements of
+// the underlying Iterator and then yields every nth element starting from the
+// next one. Panics if n is zero.
+func TakeEvery[T any](it Iterator[T], n, offset uint) Iterator[T] {
+	if n == 0 {
+		panic("TakeEvery requires a step greater than zero.")
+	}
+	return StepBy(Drop(it, offset), n)
+}
+
+// SkipEvery returns an Iterator adapter that yields the elements of the
+// underlying Iterator except for every nth one, that is, the elements at
+// positions n-1, 2n-1 and so on are dropped. If n is one, every element would be
+// dropped, so nothing is yielded and the underlying Iterator is not consumed.
+// Panics if n is zero.
+func SkipEvery[T any](it Iterator[T], n uint) Iterator[T] {
+	switch n {
+	case 0:
+		panic("SkipEvery requires a step greater than zero.")
+	case 1:
+		return Empty[T]()
+	}
+	var i uint
+	return filterStateful(it, func(T) bool {
+		i = (i + 1) % n
+		return i != 0
+	})
+}
+
+type chainAllIter[T any] struct {
+	its []Iterator[T]
+}
+
+// ChainAll returns an Iterator that concatenates any number of Iterators. Like
+// Chain, each Iterator is not polled again once it has yielded None.
+func ChainAll[T any](its ...Iterator[T]) Iterator[T] {
+	switch len(its) {
+	case 0:
+		return Empty[T]()
+	case 1:
+		return Fuse(its[0])
+	}
+	return &chainAllIter[T]{
+		its: append([]Iterator[T](nil), its...),
+	}
+}
+
+func (it *chainAllIter[T]) Next() Option[T] {
+	for len(it.its) > 0 {
+		if v := it.its[0].Next(); v.IsSome() {
+			return v
+		}
+		it.its[0] = nil
+		it.its = it.its[1:]
+	}
+	return None[T]()
+}
+
+func (it *chainAllIter[T]) size() Option[uint] {
+	var total uint
+	for _, inner := range it.its {
+		n := Len(inner)
+		if n.IsNone() {
+			return n
+		}
+		total += n.Unwrap()
+	}
+	return Some(total)
+}
+
+type prependIter[T any] struct {
+	inner  Iterator[T]
+	values []T
+}
+
+// Prepend returns an Iterator adapter that yields values before the elements of
+// the underlying Iterator.
+func Prepend[T any](it Iterator[T], values ...T) Iterator[T] {
+	return &prependIter[T]{
+		inner:  it,
+		values: values,
+	}
+}
+
+func (it *prependIter[T]) Next() Option[T] {
+	if len(it.values) > 0 {
+		v := it.values[0]
+		it.values = it.values[1:]
+		return Some(v)
+	}
+	return it.inner.Next()
+}
+
+func (it *prependIter[T]) size() Option[uint] {
+	return MapOption(Len(it.inner), func(n uint) uint {
+		return n + uint(len(it.values))
+	})
+}
+
+type appendIter[T any] struct {
+	inner  Iterator[T]
+	values []T
+}
+
+// Append returns an Iterator adapter that yields values after the elements of
+// the underlying Iterator. The underlying Iterator is not polled again once it
+// has yielded None.
+func Append[T any](it Iterator[T], values ...T) Iterator[T] {
+	return &appendIter[T]{
+		inner:  it,
+		values: values,
+	}
+}
+
+func (it *appendIter[T]) Next() Option[T] {
+	if it.inner != nil {
+		if v := it.inner.Next(); v.IsSome() {
+			return v
+		}
+		it.inner = nil
+	}
+	if len(it.values) == 0 {
+		return None[T]()
+	}
+	v := it.values[0]
+	it.values = it.values[1:]
+	return Some(v)
+}
+
+func (it *appendIter[T]) size() Option[uint] {
+	if it.inner == nil {
+		return Some(uint(len(it.values)))
+	}
+	return MapOption(Len(it.inner), func(n uint) uint {
+		return n + uint(len(it.values))
+	})
+}
+
+type flattenSliceIter[T any] struct {
+	inner   Iterator[[]T]
+	current []T
+}
+
+// FlattenSlice returns an Iterator adapter that yields the elements of each
+// slice yielded by the underlying Iterator. Empty and nil slices are skipped.
+func FlattenSlice[T any](it Iterator[[]T]) Iterator[T] {
+	return &flattenSliceIter[T]{inner: it}
+}
+
+func (it *flattenSliceIter[T]) Next() Option[T] {
+	v, ok := it.pull()
+	if !ok {
+		return None[T]()
+	}
+	return Some(v)
+}
+
+func (it *flattenSliceIter[T]) pull() (T, bool) {
+	for len(it.current) == 0 {
+		next := it.inner.Next()
+		if next.IsNone() {
+			var zero T
+			return zero, false
+		}
+		it.current = next.Unwrap()
+	}
+	v := it.current[0]
+	it.current = it.current[1:]
+	return v, true
+}
+
+func (it *flattenSliceIter[T]) drain(fn func(T)) {
+	for _, v := range it.current {
+		fn(v)
+	}
+	it.current = nil
+	ForEach(it.inner, func(s []T) {
+		for _, v := range s {
+			fn(v)
+		}
+	})
+}
+
+type withPreviousIter[T any] struct {
+	inner Iterator[T]
+	prev  Option[T]
+}
+
+// WithPrevious returns an Iterator adapter that pairs each element of the
+// underlying Iterator with the element preceding it. The first element is
+// paired with None.
+func WithPrevious[T any](it Iterator[T]) Iterator[Pair[Option[T], T]] {
+	return &withPreviousIter[T]{
+		inner: it,
+		prev:  None[T](),
+	}
+}
+
+func (it *withPreviousIter[T]) Next() Option[Pair[Option[T], T]] {
+	v := it.inner.Next()
+	if v.IsNone() {
+		return None[Pair[Option[T], T]]()
+	}
+	pair := Pair[Option[T], T]{it.prev, v.Unwrap()}
+	it.prev = v
+	return Some(pair)
+}
+
+func (it *withPreviousIter[T]) size() Option[uint] {
+	return Len(it.inner)
+}
+
+type defaultIfEmptyIter[T any] struct {
+	inner   Iterator[T]
+	def     T
+	started bool
+}
+
+// DefaultIfEmpty returns an Iterator adapter that yields the elements of the
+// underlying Iterator, or def once if the underlying Iterator is empty.
+func DefaultIfEmpty[T any](it Iterator[T], def T) Iterator[T] {
+	return &defaultIfEmptyIter[T]{
+		inner: it,
+		def:   def,
+	}
+}
+
+func (it *defaultIfEmptyIter[T]) Next() Option[T] {
+	if it.started {
+		if it.inner == nil {
+			return None[T]()
+		}
+		return it.inner.Next()
+	}
+	it.started = true
+	v := it.inner.Next()
+	if v.IsNone() {
+		it.inner = nil
+		return Some(it.def)
+	}
+	return v
+}
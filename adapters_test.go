@@ -0,0 +1,609 @@
+package iter_test
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+
+	. "github.com/Soft/iter"
+	"github.com/Soft/iter/itertest"
+)
+
+func TestEnumerate(t *testing.T) {
+	itertest.AssertYields(
+		t,
+		Enumerate(Slice([]string{"a", "b"})),
+		[]Enumerated[string]{{0, "a"}, {1, "b"}},
+	)
+	itertest.AssertYields(
+		t,
+		EnumerateFrom(Slice([]string{"a", "b"}), 10),
+		[]Enumerated[string]{{10, "a"}, {11, "b"}},
+	)
+	itertest.AssertYields(
+		t,
+		EnumerateFrom(Slice([]string{"a", "b"}), -1),
+		[]Enumerated[string]{{-1, "a"}, {0, "b"}},
+	)
+	itertest.AssertYields(t, EnumerateFrom(Empty[string](), 10), nil)
+}
+
+func TestStepBy(t *testing.T) {
+	itertest.AssertYields(t, StepBy(Range(0, 10, 1), 3), []int{0, 3, 6, 9})
+	itertest.AssertYields(t, StepBy(Range(0, 9, 1), 3), []int{0, 3, 6})
+	itertest.AssertYields(t, StepBy(Range(0, 5, 1), 1), []int{0, 1, 2, 3, 4})
+	itertest.AssertYields(t, StepBy(Empty[int](), 2), nil)
+	defer func() {
+		equals(t, recover() != nil, true)
+	}()
+	StepBy(Empty[int](), 0)
+}
+
+func TestChunks(t *testing.T) {
+	itertest.AssertYields(t, Chunks(Range(0, 7, 1), 3), [][]int{{0, 1, 2}, {3, 4, 5}, {6}})
+	itertest.AssertYields(t, Chunks(Range(0, 6, 1), 3), [][]int{{0, 1, 2}, {3, 4, 5}})
+	itertest.AssertYields(t, Chunks(Empty[int](), 3), nil)
+	it := Chunks(Range(0, 4, 1), 2)
+	first := it.Next().Unwrap()
+	second := it.Next().Unwrap()
+	first[0] = 100
+	equals(t, second, []int{2, 3})
+	equals(t, cap(first), 2)
+	defer func() {
+		equals(t, recover() != nil, true)
+	}()
+	Chunks(Empty[int](), 0)
+}
+
+func TestChunksExact(t *testing.T) {
+	it := ChunksExact(Range(1, 8, 1), 3)
+	equals(t, it.Remainder(), []int{})
+	itertest.AssertYields[[]int](t, it, [][]int{{1, 2, 3}, {4, 5, 6}})
+	equals(t, it.Remainder(), []int{7})
+	itertest.AssertFused[[]int](t, it)
+	equals(t, it.Remainder(), []int{7})
+	it = ChunksExact(Range(1, 7, 1), 3)
+	itertest.AssertYields[[]int](t, it, [][]int{{1, 2, 3}, {4, 5, 6}})
+	equals(t, it.Remainder(), []int{})
+}
+
+func TestWindows(t *testing.T) {
+	itertest.AssertYields(t, Windows(Range(0, 5, 1), 3), [][]int{{0, 1, 2}, {1, 2, 3}, {2, 3, 4}})
+	itertest.AssertYields(t, Windows(Range(0, 3, 1), 3), [][]int{{0, 1, 2}})
+	itertest.AssertYields(t, Windows(Range(0, 2, 1), 3), nil)
+	itertest.AssertYields(t, Windows(Range(0, 3, 1), 1), [][]int{{0}, {1}, {2}})
+	it := Windows(Range(0, 4, 1), 2)
+	first := it.Next().Unwrap()
+	second := it.Next().Unwrap()
+	first[1] = 100
+	equals(t, second, []int{1, 2})
+	defer func() {
+		equals(t, recover() != nil, true)
+	}()
+	Windows(Empty[int](), 0)
+}
+
+func BenchmarkWindows(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		Count(Windows(Range(0, benchSize/10, 1), 64))
+	}
+}
+
+func TestWindowsStep(t *testing.T) {
+	itertest.AssertYields(t, WindowsStep(Range(0, 7, 1), 4, 2), [][]int{{0, 1, 2, 3}, {2, 3, 4, 5}})
+	itertest.AssertYields(t, WindowsStep(Range(0, 8, 1), 4, 2), [][]int{{0, 1, 2, 3}, {2, 3, 4, 5}, {4, 5, 6, 7}})
+	itertest.AssertYields(t, WindowsStep(Range(0, 5, 1), 3, 1), ToSlice(Windows(Range(0, 5, 1), 3)))
+	itertest.AssertYields(t, WindowsStep(Range(0, 7, 1), 3, 3), [][]int{{0, 1, 2}, {3, 4, 5}})
+	itertest.AssertYields(t, WindowsStep(Range(0, 2, 1), 3, 1), nil)
+	itertest.AssertFused(t, WindowsStep(resurrecting(), 2, 1))
+}
+
+func TestWindowsStepSkips(t *testing.T) {
+	itertest.AssertYields(t, WindowsStep(Range(0, 10, 1), 2, 3), [][]int{{0, 1}, {3, 4}, {6, 7}})
+	itertest.AssertYields(t, WindowsStep(Range(0, 9, 1), 2, 3), [][]int{{0, 1}, {3, 4}, {6, 7}})
+	itertest.AssertYields(t, WindowsStep(Range(0, 7, 1), 2, 3), [][]int{{0, 1}, {3, 4}})
+	itertest.AssertYields(t, WindowsStep(Range(0, 10, 1), 1, 4), [][]int{{0}, {4}, {8}})
+	defer func() {
+		equals(t, recover() != nil, true)
+	}()
+	WindowsStep(Empty[int](), 2, 0)
+}
+
+func TestScan(t *testing.T) {
+	add := func(acc, i int) int {
+		return acc + i
+	}
+	itertest.AssertYields(t, Scan(Range(1, 5, 1), 0, add), []int{1, 3, 6, 10})
+	itertest.AssertYields(t, Scan(Empty[int](), 0, add), nil)
+	counting := itertest.Counting(Range(1, 5, 1))
+	it := Scan[int](counting, "", func(acc string, i int) string {
+		return acc + strconv.Itoa(i)
+	})
+	equals(t, it.Next().Unwrap(), "1")
+	equals(t, counting.Calls(), 1)
+	equals(t, Len(Scan(Slice([]int{1, 2}), 0, add)), Some[uint](2))
+}
+
+func TestPeekable(t *testing.T) {
+	counting := itertest.Counting(Slice([]int{1, 2}))
+	it := Peekable[int](counting)
+	equals(t, it.Peek(), Some(1))
+	equals(t, it.Peek(), Some(1))
+	equals(t, counting.Calls(), 1)
+	equals(t, it.Next(), Some(1))
+	equals(t, it.Next(), Some(2))
+	equals(t, it.Peek(), None[int]())
+	equals(t, it.Peek(), None[int]())
+	itertest.AssertFused[int](t, it)
+	equals(t, Peekable[int](it), it)
+}
+
+func TestPeekableLen(t *testing.T) {
+	it := Peekable(Slice([]int{1, 2}))
+	equals(t, Len[int](it), Some[uint](2))
+	it.Peek()
+	equals(t, Len[int](it), Some[uint](2))
+	it.Next()
+	equals(t, Len[int](it), Some[uint](1))
+}
+
+func TestPeekableEmpty(t *testing.T) {
+	it := Peekable(Empty[int]())
+	equals(t, it.Peek(), None[int]())
+	equals(t, it.Peek(), None[int]())
+	itertest.AssertFused[int](t, it)
+}
+
+func TestTakeWhilePeek(t *testing.T) {
+	below := func(i int) bool {
+		return i < 3
+	}
+	source := Range(0, 6, 1)
+	equals(t, ToSlice(TakeWhile(source, below)), []int{0, 1, 2})
+	equals(t, ToSlice(source), []int{4, 5})
+	peekable := Peekable(Range(0, 6, 1))
+	equals(t, ToSlice(TakeWhilePeek(peekable, below)), []int{0, 1, 2})
+	equals(t, ToSlice[int](peekable), []int{3, 4, 5})
+	itertest.AssertYields(t, TakeWhilePeek(Peekable(Range(0, 2, 1)), below), []int{0, 1})
+	itertest.AssertFused(t, TakeWhilePeek(Peekable(Empty[int]()), below))
+}
+
+func TestCycle(t *testing.T) {
+	equals(t, ToSlice(Take(Cycle(Slice([]int{1, 2, 3})), 7)), []int{1, 2, 3, 1, 2, 3, 1})
+	equals(t, ToSlice(Take(Cycle(Slice([]int{1})), 3)), []int{1, 1, 1})
+	itertest.AssertFused(t, Cycle(Empty[int]()))
+	counting := itertest.Counting(Slice([]int{1, 2}))
+	equals(t, ToSlice(Take(Cycle[int](counting), 6)), []int{1, 2, 1, 2, 1, 2})
+	equals(t, counting.Calls(), 3)
+}
+
+func TestCycleN(t *testing.T) {
+	itertest.AssertYields(t, CycleN(Slice([]int{1, 2}), 3), []int{1, 2, 1, 2, 1, 2})
+	itertest.AssertYields(t, CycleN(Slice([]int{1, 2}), 1), []int{1, 2})
+	itertest.AssertYields(t, CycleN(Slice([]int{1, 2}), 0), nil)
+	itertest.AssertYields(t, CycleN(Empty[int](), 3), nil)
+	itertest.AssertYields(t, CycleN(Empty[int](), 0), nil)
+	counting := itertest.Counting(Slice([]int{1, 2}))
+	itertest.AssertYields(t, CycleN[int](counting, 0), nil)
+	equals(t, counting.Calls(), 0)
+	it := CycleN(Slice([]int{1}), 3)
+	itertest.AssertYields(t, it, []int{1, 1, 1})
+	itertest.AssertFused(t, it)
+}
+
+func TestDedup(t *testing.T) {
+	itertest.AssertYields(t, Dedup(Slice([]int{1, 1, 2, 2, 2, 3, 1})), []int{1, 2, 3, 1})
+	itertest.AssertYields(t, Dedup(Slice([]int{0, 0, 1})), []int{0, 1})
+	itertest.AssertYields(t, Dedup(Slice([]string{"a"})), []string{"a"})
+	itertest.AssertYields(t, Dedup(Empty[int]()), nil)
+}
+
+func TestDedupBy(t *testing.T) {
+	type entry struct {
+		level string
+		time  int
+	}
+	entries := []entry{{"info", 1}, {"info", 2}, {"warn", 3}, {"warn", 4}, {"info", 5}}
+	itertest.AssertYields(
+		t,
+		DedupBy(Slice(entries), func(prev, cur entry) bool {
+			return prev.level == cur.level
+		}),
+		[]entry{{"info", 1}, {"warn", 3}, {"info", 5}},
+	)
+	// Elements are compared against the first element of the run.
+	itertest.AssertYields(
+		t,
+		DedupBy(Slice([]int{1, 2, 3, 4, 5}), func(prev, cur int) bool {
+			return cur-prev < 2
+		}),
+		[]int{1, 3, 5},
+	)
+	itertest.AssertYields(t, DedupBy(Empty[int](), func(int, int) bool { return true }), nil)
+}
+
+func TestUnique(t *testing.T) {
+	itertest.AssertYields(t, Unique(Slice([]int{3, 1, 3, 2, 1})), []int{3, 1, 2})
+	itertest.AssertYields(t, Unique(Empty[int]()), nil)
+	counting := itertest.Counting(Cycle(Slice([]int{1, 2, 3})))
+	equals(t, ToSlice(Take(Unique[int](counting), 3)), []int{1, 2, 3})
+	equals(t, counting.Calls(), 3)
+}
+
+func TestUniqueBy(t *testing.T) {
+	type user struct {
+		email string
+		name  string
+	}
+	users := []user{{"a@x", "first"}, {"b@x", "second"}, {"a@x", "third"}, {"b@x", "fourth"}}
+	itertest.AssertYields(
+		t,
+		UniqueBy(Slice(users), func(u user) string {
+			return u.email
+		}),
+		[]user{{"a@x", "first"}, {"b@x", "second"}},
+	)
+	itertest.AssertYields(t, UniqueBy(Range(0, 10, 1), func(i int) int { return i % 3 }), []int{0, 1, 2})
+}
+
+func TestInspect(t *testing.T) {
+	var seen []int
+	pipeline := func(source Iterator[int]) Iterator[int] {
+		return Filter(Map(source, square), isOdd)
+	}
+	got := ToSlice(Inspect(pipeline(Range(0, 6, 1)), func(i int) {
+		seen = append(seen, i)
+	}))
+	equals(t, got, ToSlice(pipeline(Range(0, 6, 1))))
+	equals(t, seen, got)
+	calls := 0
+	itertest.AssertFused(t, Inspect(Empty[int](), func(int) {
+		calls++
+	}))
+	equals(t, calls, 0)
+}
+
+func TestIntersperse(t *testing.T) {
+	itertest.AssertYields(t, Intersperse(Slice([]string{"a", "b", "c"}), ","), []string{"a", ",", "b", ",", "c"})
+	itertest.AssertYields(t, Intersperse(Slice([]string{"a"}), ","), []string{"a"})
+	itertest.AssertYields(t, Intersperse(Empty[string](), ","), nil)
+}
+
+func TestIntersperseWith(t *testing.T) {
+	calls := 0
+	sep := func() int {
+		calls++
+		return -calls
+	}
+	it := IntersperseWith(Range(1, 5, 1), sep)
+	equals(t, it.Next(), Some(1))
+	equals(t, calls, 0)
+	itertest.AssertYields(t, it, []int{-1, 2, -2, 3, -3, 4})
+	equals(t, calls, 3)
+	itertest.AssertFused(t, it)
+	equals(t, calls, 3)
+	calls = 0
+	itertest.AssertYields(t, IntersperseWith(Empty[int](), sep), nil)
+	equals(t, calls, 0)
+}
+
+func TestInterleave(t *testing.T) {
+	itertest.AssertYields(t, Interleave(Range(0, 3, 1), Range(10, 13, 1)), []int{0, 10, 1, 11, 2, 12})
+	itertest.AssertYields(t, Interleave(Range(0, 5, 1), Range(10, 12, 1)), []int{0, 10, 1, 11, 2, 3, 4})
+	itertest.AssertYields(t, Interleave(Range(0, 2, 1), Range(10, 15, 1)), []int{0, 10, 1, 11, 12, 13, 14})
+	itertest.AssertYields(t, Interleave(Empty[int](), Range(10, 12, 1)), []int{10, 11})
+	it := Interleave(resurrecting(), Empty[int]())
+	equals(t, it.Next(), Some(1))
+	itertest.AssertFused(t, it)
+}
+
+func TestRoundRobin(t *testing.T) {
+	itertest.AssertYields(
+		t,
+		RoundRobin(Slice([]int{1, 2, 3}), Slice([]int{10}), Slice([]int{20, 21})),
+		[]int{1, 10, 20, 2, 21, 3},
+	)
+	itertest.AssertYields(t, RoundRobin[int](), nil)
+	itertest.AssertYields(t, RoundRobin(Empty[int](), Slice([]int{1, 2})), []int{1, 2})
+	first := itertest.Counting(resurrecting())
+	second := itertest.Counting(Slice([]int{10, 11, 12}))
+	it := RoundRobin[int](first, second)
+	itertest.AssertYields(t, it, []int{1, 10, 11, 12})
+	itertest.AssertFused(t, it)
+	equals(t, first.Calls(), 2)
+	equals(t, second.Calls(), 4)
+}
+
+func TestMapWhile(t *testing.T) {
+	parse := func(s string) Option[int] {
+		n, err := strconv.Atoi(s)
+		if err != nil {
+			return None[int]()
+		}
+		return Some(n)
+	}
+	counting := itertest.Counting(Slice([]string{"1", "2", "x", "3", "4"}))
+	it := MapWhile[string](counting, parse)
+	itertest.AssertYields(t, it, []int{1, 2})
+	itertest.AssertFused(t, it)
+	equals(t, counting.Calls(), 3)
+	itertest.AssertYields(t, MapWhile(Slice([]string{"1", "2"}), parse), []int{1, 2})
+	itertest.AssertFused(t, MapWhile(resurrecting(), func(i int) Option[int] {
+		return None[int]()
+	}))
+}
+
+func TestBatching(t *testing.T) {
+	// Group strings into batches of at most 8 bytes in total. Strings longer
+	// than that are batched on their own.
+	bounded := func(it PeekableIterator[string]) Option[[]string] {
+		var batch []string
+		size := 0
+		for {
+			v := it.Peek()
+			if v.IsNone() || (len(batch) > 0 && size+len(v.Unwrap()) > 8) {
+				break
+			}
+			batch = append(batch, v.Unwrap())
+			size += len(v.Unwrap())
+			it.Next()
+		}
+		if len(batch) == 0 {
+			return None[[]string]()
+		}
+		return Some(batch)
+	}
+	itertest.AssertYields(
+		t,
+		Batching(Slice([]string{"abc", "de", "fgh", "ijklmnopq", "r", "stuvw", "xyz"}), bounded),
+		[][]string{{"abc", "de", "fgh"}, {"ijklmnopq"}, {"r", "stuvw"}, {"xyz"}},
+	)
+	itertest.AssertYields(t, Batching(Empty[string](), bounded), nil)
+	itertest.AssertFused(t, Batching(Empty[string](), bounded))
+}
+
+func TestCoalesce(t *testing.T) {
+	overlapping := func(prev, cur Pair[int, int]) Option[Pair[int, int]] {
+		if cur.First > prev.Second {
+			return None[Pair[int, int]]()
+		}
+		if cur.Second > prev.Second {
+			prev.Second = cur.Second
+		}
+		return Some(prev)
+	}
+	itertest.AssertYields(
+		t,
+		Coalesce(Slice([]Pair[int, int]{{1, 3}, {2, 5}, {4, 6}, {8, 9}, {10, 12}, {11, 11}}), overlapping),
+		[]Pair[int, int]{{1, 6}, {8, 9}, {10, 12}},
+	)
+	itertest.AssertYields(
+		t,
+		Coalesce(Slice([]Pair[int, int]{{1, 2}}), overlapping),
+		[]Pair[int, int]{{1, 2}},
+	)
+	itertest.AssertFused(t, Coalesce(Empty[Pair[int, int]](), overlapping))
+}
+
+func TestPairwise(t *testing.T) {
+	itertest.AssertYields(t, Pairwise(Range(0, 4, 1)), []Pair[int, int]{{0, 1}, {1, 2}, {2, 3}})
+	itertest.AssertYields(t, Pairwise(Range(0, 1, 1)), nil)
+	itertest.AssertYields(t, Pairwise(Empty[int]()), nil)
+}
+
+func BenchmarkPairwise(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		Count(Pairwise(Range(0, benchSize, 1)))
+	}
+}
+
+func BenchmarkPairwiseWindows(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		Count(Windows(Range(0, benchSize, 1), 2))
+	}
+}
+
+func TestCartesianProduct(t *testing.T) {
+	itertest.AssertYields(
+		t,
+		CartesianProduct[int, string](Range(0, 3, 1), Slice([]string{"a", "b"})),
+		[]Pair[int, string]{{0, "a"}, {0, "b"}, {1, "a"}, {1, "b"}, {2, "a"}, {2, "b"}},
+	)
+	itertest.AssertYields(t, CartesianProduct[int, string](Empty[int](), Slice([]string{"a"})), nil)
+	itertest.AssertFused(t, CartesianProduct[int, string](Repeat(1), Empty[string]()))
+	equals(
+		t,
+		ToSlice(Take(CartesianProduct[int, string](Repeat(1), Slice([]string{"a", "b"})), 5)),
+		[]Pair[int, string]{{1, "a"}, {1, "b"}, {1, "a"}, {1, "b"}, {1, "a"}},
+	)
+	counting := itertest.Counting(Slice([]string{"a", "b"}))
+	it := CartesianProduct[int, string](Range(0, 3, 1), counting)
+	itertest.AssertYields(t, it, ToSlice(CartesianProduct[int, string](Range(0, 3, 1), Slice([]string{"a", "b"}))))
+	itertest.AssertFused(t, it)
+	equals(t, counting.Calls(), 3)
+}
+
+func TestCombinations(t *testing.T) {
+	itertest.AssertYields(
+		t,
+		Combinations(Range(0, 4, 1), 2),
+		[][]int{{0, 1}, {0, 2}, {0, 3}, {1, 2}, {1, 3}, {2, 3}},
+	)
+	itertest.AssertYields(t, Combinations(Range(0, 3, 1), 3), [][]int{{0, 1, 2}})
+	itertest.AssertYields(t, Combinations(Range(0, 3, 1), 0), [][]int{{}})
+	itertest.AssertYields(t, Combinations(Empty[int](), 0), [][]int{{}})
+	itertest.AssertYields(t, Combinations(Range(0, 3, 1), 4), nil)
+	equals(t, Count(Combinations(Range(0, 5, 1), 2)), uint(10))
+	it := Combinations(Range(0, 3, 1), 1)
+	first := it.Next().Unwrap()
+	first[0] = 100
+	itertest.AssertYields(t, it, [][]int{{1}, {2}})
+	itertest.AssertFused(t, it)
+}
+
+func TestGroupAdjacentBy(t *testing.T) {
+	first := func(s string) byte {
+		return s[0]
+	}
+	itertest.AssertYields(
+		t,
+		GroupAdjacentBy(Slice([]string{"a1", "a2", "b1", "a3", "c1", "c2"}), first),
+		[]Group[byte, string]{
+			{'a', []string{"a1", "a2"}},
+			{'b', []string{"b1"}},
+			{'a', []string{"a3"}},
+			{'c', []string{"c1", "c2"}},
+		},
+	)
+	itertest.AssertFused(t, GroupAdjacentBy(Empty[string](), first))
+}
+
+func TestSplitBy(t *testing.T) {
+	isComma := func(r rune) bool {
+		return r == ','
+	}
+	split := func(s string) []string {
+		return ToSlice(Map(SplitBy(String(s), isComma), func(rs []rune) string {
+			return string(rs)
+		}))
+	}
+	for _, input := range []string{"a,bc,d", ",a", "a,", "a,,b", ",", "", "abc"} {
+		equals(t, split(input), strings.Split(input, ","))
+	}
+	itertest.AssertYields(t, SplitBy(Slice([]int{1, 0, 0, 2}), func(i int) bool { return i == 0 }), [][]int{{1}, {}, {2}})
+	itertest.AssertFused(t, Drop(SplitBy(Empty[int](), func(int) bool { return true }), 1))
+}
+
+func TestPadTo(t *testing.T) {
+	itertest.AssertYields(t, PadTo(Slice([]int{1, 2}), 4, 0), []int{1, 2, 0, 0})
+	itertest.AssertYields(t, PadTo(Slice([]int{1, 2}), 2, 0), []int{1, 2})
+	itertest.AssertYields(t, PadTo(Slice([]int{1, 2, 3}), 2, 0), []int{1, 2, 3})
+	itertest.AssertYields(t, PadTo(Empty[int](), 3, 7), []int{7, 7, 7})
+	itertest.AssertYields(t, PadTo(Empty[int](), 0, 7), nil)
+	it := PadTo(resurrecting(), 3, 0)
+	itertest.AssertYields(t, it, []int{1, 0, 0})
+	itertest.AssertFused(t, it)
+	equals(t, Len(PadTo(Slice([]int{1, 2}), 4, 0)), Some[uint](4))
+	equals(t, Len(PadTo(Slice([]int{1, 2, 3}), 2, 0)), Some[uint](3))
+	padded := PadTo(Slice([]int{1}), 3, 0)
+	padded.Next()
+	padded.Next()
+	equals(t, Len(padded), Some[uint](1))
+}
+
+func TestTakeEvery(t *testing.T) {
+	itertest.AssertYields(t, TakeEvery(Range(0, 10, 1), 3, 1), []int{1, 4, 7})
+	itertest.AssertYields(t, TakeEvery(Range(0, 10, 1), 3, 0), []int{0, 3, 6, 9})
+	itertest.AssertYields(t, TakeEvery(Range(0, 10, 1), 3, 10), nil)
+	var union []int
+	for offset := uint(0); offset < 4; offset++ {
+		union = append(union, ToSlice(TakeEvery(Range(0, 10, 1), 4, offset))...)
+	}
+	sort.Ints(union)
+	equals(t, union, ToSlice(Range(0, 10, 1)))
+	defer func() {
+		equals(t, recover() != nil, true)
+	}()
+	TakeEvery(Empty[int](), 0, 1)
+}
+
+func TestSkipEvery(t *testing.T) {
+	itertest.AssertYields(t, SkipEvery(Range(0, 10, 1), 3), []int{0, 1, 3, 4, 6, 7, 9})
+	itertest.AssertYields(t, SkipEvery(Range(0, 10, 1), 10), []int{0, 1, 2, 3, 4, 5, 6, 7, 8})
+	itertest.AssertYields(t, SkipEvery(Range(0, 10, 1), 1), nil)
+	itertest.AssertYields(t, SkipEvery(Repeat(1), 1), nil)
+	defer func() {
+		equals(t, recover() != nil, true)
+	}()
+	SkipEvery(Empty[int](), 0)
+}
+
+func TestChainAll(t *testing.T) {
+	itertest.AssertYields(
+		t,
+		ChainAll(Empty[int](), Range(0, 2, 1), Empty[int](), Slice([]int{5}), Range(7, 9, 1)),
+		[]int{0, 1, 5, 7, 8},
+	)
+	itertest.AssertYields(t, ChainAll[int](), nil)
+	itertest.AssertYields(t, ChainAll(Empty[int](), Empty[int]()), nil)
+	itertest.AssertYields(t, ChainAll(Range(0, 2, 1)), []int{0, 1})
+	first := itertest.Counting(resurrecting())
+	it := ChainAll[int](first, resurrecting())
+	itertest.AssertYields(t, it, []int{1, 1})
+	itertest.AssertFused(t, it)
+	equals(t, first.Calls(), 2)
+	equals(t, Len(ChainAll(Range(0, 2, 1), Slice([]int{5}))), Some[uint](3))
+}
+
+func TestPrepend(t *testing.T) {
+	itertest.AssertYields(t, Prepend(Range(3, 5, 1), 0, 1, 2), []int{0, 1, 2, 3, 4})
+	itertest.AssertYields(t, Prepend(Range(3, 5, 1)), []int{3, 4})
+	itertest.AssertYields(t, Prepend(Empty[int](), 1), []int{1})
+	equals(t, Len(Prepend(Range(3, 5, 1), 0, 1)), Some[uint](4))
+}
+
+func TestAppend(t *testing.T) {
+	itertest.AssertYields(t, Append(Range(0, 2, 1), 2, 3, 4), []int{0, 1, 2, 3, 4})
+	itertest.AssertYields(t, Append(Range(0, 2, 1)), []int{0, 1})
+	itertest.AssertYields(t, Append(Empty[int](), 1), []int{1})
+	it := Append(resurrecting(), 2)
+	itertest.AssertYields(t, it, []int{1, 2})
+	itertest.AssertFused(t, it)
+	equals(t, Len(Append(Range(0, 2, 1), 2, 3)), Some[uint](4))
+}
+
+func TestFlattenSlice(t *testing.T) {
+	itertest.AssertYields(
+		t,
+		FlattenSlice(Slice([][]int{{}, {1, 2}, nil, {3}, {}, {4, 5}, nil})),
+		[]int{1, 2, 3, 4, 5},
+	)
+	itertest.AssertYields(t, FlattenSlice(Chunks(Range(0, 5, 1), 2)), []int{0, 1, 2, 3, 4})
+	itertest.AssertYields(t, FlattenSlice(Slice([][]int{nil, {}})), nil)
+	itertest.AssertYields(t, FlattenSlice(Empty[[]int]()), nil)
+	it := FlattenSlice(Slice([][]int{{1, 2}, {}, {3, 4}}))
+	equals(t, ToSlice(Take(it, 3)), []int{1, 2, 3})
+	itertest.AssertYields(t, it, []int{4})
+	itertest.AssertYields(t, Map(FlattenSlice(Slice([][]int{{1}, {2}})), square), []int{1, 4})
+}
+
+func BenchmarkFlattenSlice(b *testing.B) {
+	chunks := ToSlice(Chunks(Range(0, benchSize, 1), 16))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		sum(FlattenSlice(Slice(chunks)))
+	}
+}
+
+func BenchmarkFlattenMapSlice(b *testing.B) {
+	chunks := ToSlice(Chunks(Range(0, benchSize, 1), 16))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		sum(Flatten(Map(Slice(chunks), Slice[int])))
+	}
+}
+
+func TestWithPrevious(t *testing.T) {
+	itertest.AssertYields(
+		t,
+		WithPrevious(Range(0, 3, 1)),
+		[]Pair[Option[int], int]{{None[int](), 0}, {Some(0), 1}, {Some(1), 2}},
+	)
+	itertest.AssertYields(t, WithPrevious(Slice([]int{5})), []Pair[Option[int], int]{{None[int](), 5}})
+	itertest.AssertYields(t, WithPrevious(Empty[int]()), nil)
+	equals(t, Len(WithPrevious(Range(0, 3, 1))), Some[uint](3))
+}
+
+func TestDefaultIfEmpty(t *testing.T) {
+	itertest.AssertYields(t, DefaultIfEmpty(Range(0, 3, 1), -1), []int{0, 1, 2})
+	it := DefaultIfEmpty(Empty[int](), -1)
+	itertest.AssertYields(t, it, []int{-1})
+	itertest.AssertFused(t, it)
+	itertest.AssertYields(t, DefaultIfEmpty(Filter(Range(0, 3, 1), func(int) bool { return false }), -1), []int{-1})
+}
@@ -0,0 +1,163 @@
+package iter
+
+// DoubleEnded[T] represents an Iterator that is able to yield elements from
+// both ends.
+type DoubleEnded[T any] interface {
+	Iterator[T]
+	// NextBack yields a new value from the back of the Iterator.
+	NextBack() Option[T]
+}
+
+// backer is implemented by Iterators that are able to yield elements from the
+// back only under some conditions, such as adapters wrapping a DoubleEnded
+// Iterator. back reports false without consuming any elements if the Iterator
+// is not able to yield from the back.
+type backer[T any] interface {
+	back() (Option[T], bool)
+}
+
+// nextBack yields a value from the back of the Iterator. It reports false if
+// the Iterator is not able to yield values from the back.
+func nextBack[T any](it Iterator[T]) (Option[T], bool) {
+	switch b := it.(type) {
+	case DoubleEnded[T]:
+		return b.NextBack(), true
+	case backer[T]:
+		return b.back()
+	}
+	return None[T](), false
+}
+
+func (it *sliceIter[T]) NextBack() Option[T] {
+	if len(it.slice) == 0 {
+		return None[T]()
+	}
+	last := it.slice[len(it.slice)-1]
+	it.slice = it.slice[:len(it.slice)-1]
+	return Some(last)
+}
+
+func (it *rangeIter) back() (Option[int], bool) {
+	switch {
+	case !it.remaining():
+		return None[int](), true
+	case it.step == 0:
+		return None[int](), false
+	}
+	d, step := it.distance()
+	n := (d - 1) / step
+	if it.inclusive {
+		n = d / step
+	}
+	// The offset may not fit in int but wraps around to the correct value.
+	last := int(uint64(it.next) + uint64(it.step)*n)
+	it.stop = last
+	it.inclusive = false
+	return Some(last), true
+}
+
+func (it *mapIter[T, R]) back() (Option[R], bool) {
+	v, ok := nextBack(it.inner)
+	if !ok {
+		return None[R](), false
+	}
+	return MapOption(v, it.fn), true
+}
+
+func (it *takeIter[T]) back() (Option[T], bool) {
+	if it.take == 0 {
+		return None[T](), true
+	}
+	n := Len(it.inner)
+	if n.IsNone() {
+		return None[T](), false
+	}
+	for skip := n.Unwrap(); skip > it.take; skip-- {
+		if _, ok := nextBack(it.inner); !ok {
+			return None[T](), false
+		}
+	}
+	v, ok := nextBack(it.inner)
+	if ok && v.IsSome() {
+		it.take--
+	}
+	return v, ok
+}
+
+// Last consumes the Iterator returning the last element. Iterators able to
+// yield elements from the back are not drained.
+func Last[T any](it Iterator[T]) Option[T] {
+	if v, ok := nextBack(it); ok {
+		return v
+	}
+	last := None[T]()
+	ForEach(it, func(v T) {
+		last = Some(v)
+	})
+	return last
+}
+
+// NthBack returns nth element from the end of the Iterator. Iterators able to
+// yield elements from the back are not drained.
+func NthBack[T any](it Iterator[T], n uint) Option[T] {
+	if v, ok := nextBack(it); ok {
+		for ; n > 0 && v.IsSome(); n-- {
+			v, _ = nextBack(it)
+		}
+		return v
+	}
+	// Keep the last n+1 elements in a ring buffer. The buffer grows as needed
+	// since n may be far larger than the number of elements.
+	var ring []T
+	var i uint
+	ForEach(it, func(v T) {
+		if uint(len(ring)) <= n {
+			ring = append(ring, v)
+		} else {
+			ring[i] = v
+			i = (i + 1) % (n + 1)
+		}
+	})
+	if uint(len(ring)) <= n {
+		return None[T]()
+	}
+	return Some(ring[i])
+}
+
+type reverseIter[T any] struct {
+	inner   Iterator[T]
+	buf     []T
+	started bool
+	back    bool
+}
+
+// Reverse returns an Iterator adapter that yields the elements of the underlying
+// Iterator in reverse order. Nothing is consumed before the first call to Next.
+// Iterators able to yield elements from the back are read from the back
+// directly; other Iterators are first collected into a slice. Reverse does not
+// terminate if the underlying Iterator is infinite.
+func Reverse[T any](it Iterator[T]) Iterator[T] {
+	return &reverseIter[T]{inner: it}
+}
+
+func (it *reverseIter[T]) Next() Option[T] {
+	if !it.started {
+		it.started = true
+		if v, ok := nextBack(it.inner); ok {
+			it.back = true
+			return v
+		}
+		it.buf = ToSlice(it.inner)
+		it.inner = nil
+	}
+	if it.back {
+		v, _ := nextBack(it.inner)
+		return v
+	}
+	if len(it.buf) == 0 {
+		return None[T]()
+	}
+	last := it.buf[len(it.buf)-1]
+	it.buf = it.buf[:len(it.buf)-1]
+	return Some(last)
+}
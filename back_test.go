@@ -0,0 +1,103 @@
+package iter_test
+
+import (
+	"math"
+	"testing"
+
+	. "github.com/Soft/iter"
+	"github.com/Soft/iter/itertest"
+)
+
+// opaque hides the concrete type of an Iterator so that it is not able to
+// yield elements from the back.
+func opaque[T any](it Iterator[T]) Iterator[T] {
+	return Func(it.Next)
+}
+
+func TestNextBack(t *testing.T) {
+	it := Slice([]int{1, 2, 3, 4}).(DoubleEnded[int])
+	equals(t, it.NextBack().Unwrap(), 4)
+	equals(t, it.Next().Unwrap(), 1)
+	equals(t, it.NextBack().Unwrap(), 3)
+	equals(t, it.Next().Unwrap(), 2)
+	equals(t, it.NextBack().IsNone(), true)
+	equals(t, it.Next().IsNone(), true)
+}
+
+func TestLast(t *testing.T) {
+	equals(t, Last(Slice([]int{1, 2, 3})), Some(3))
+	equals(t, Last(opaque(Slice([]int{1, 2, 3}))), Some(3))
+	equals(t, Last(Empty[int]()), None[int]())
+	equals(t, Last(Slice([]int{})), None[int]())
+	equals(t, Last(Range(0, 10, 3)), Some(9))
+	equals(t, Last(Range(10, 0, -3)), Some(1))
+	equals(t, Last(Map(Slice([]int{1, 2, 3}), square)), Some(9))
+	equals(t, Last(Take(Slice([]int{1, 2, 3}), 2)), Some(2))
+	equals(t, Last(Take(opaque(Slice([]int{1, 2, 3})), 2)), Some(2))
+}
+
+func TestNthBack(t *testing.T) {
+	sources := map[string]func() Iterator[int]{
+		"slice": func() Iterator[int] {
+			return Slice([]int{1, 2, 3, 4, 5})
+		},
+		"func": func() Iterator[int] {
+			return opaque(Slice([]int{1, 2, 3, 4, 5}))
+		},
+		"range": func() Iterator[int] {
+			return Range(1, 6, 1)
+		},
+		"take": func() Iterator[int] {
+			return Take(Range(1, 100, 1), 5)
+		},
+	}
+	for name, source := range sources {
+		t.Run(name, func(t *testing.T) {
+			equals(t, NthBack(source(), 0), Some(5))
+			equals(t, NthBack(source(), 2), Some(3))
+			equals(t, NthBack(source(), 4), Some(1))
+			equals(t, NthBack(source(), 5), None[int]())
+		})
+	}
+	equals(t, NthBack(opaque(Slice([]int{1, 2, 3})), 1<<62), None[int]())
+	equals(t, NthBack(opaque(Slice([]int{1, 2, 3})), math.MaxUint), None[int]())
+}
+
+func TestRangeNextBack(t *testing.T) {
+	it := Range(0, 10, 3)
+	equals(t, Last(it), Some(9))
+	equals(t, ToSlice(it), []int{0, 3, 6})
+}
+
+func TestRangeNextBackLimits(t *testing.T) {
+	equals(t, Last(Range(0, 10, math.MaxInt)), Some(0))
+	equals(t, Last(Range(0, -10, math.MinInt)), Some(0))
+	equals(t, Last(Range(math.MinInt, math.MaxInt, 1)), Some(math.MaxInt-1))
+	equals(t, Last(Range(math.MaxInt, math.MinInt, -1)), Some(math.MinInt+1))
+	equals(t, Last(Range(math.MinInt, math.MaxInt, math.MaxInt)), Some(math.MaxInt-1))
+	equals(t, NthBack(Range(math.MinInt, math.MaxInt, 1), 2), Some(math.MaxInt-3))
+	equals(t, NthBack(Range(math.MinInt, math.MaxInt, math.MaxInt), 2), Some(math.MinInt))
+	equals(t, NthBack(Range(0, 10, math.MaxInt), 1), None[int]())
+	itertest.AssertYields(t, Reverse(Range(math.MinInt, math.MaxInt, math.MaxInt)), []int{math.MaxInt - 1, -1, math.MinInt})
+}
+
+func TestReverse(t *testing.T) {
+	itertest.AssertYields(t, Reverse(Slice([]int{1, 2, 3})), []int{3, 2, 1})
+	itertest.AssertYields(t, Reverse(opaque(Slice([]int{1, 2, 3}))), []int{3, 2, 1})
+	itertest.AssertYields(t, Reverse(Range(0, 10, 3)), []int{9, 6, 3, 0})
+	itertest.AssertYields(t, Reverse(Range(10, 0, -3)), []int{1, 4, 7, 10})
+	itertest.AssertYields(t, Reverse(Map(Range(1, 4, 1), square)), []int{9, 4, 1})
+	itertest.AssertYields(t, Reverse(Empty[int]()), nil)
+	itertest.AssertFused(t, Reverse(Range(0, 0, 1)))
+	itertest.AssertFused(t, Reverse(opaque(Empty[int]())))
+}
+
+func TestReverseLazy(t *testing.T) {
+	counting := itertest.Counting(Slice([]int{1, 2, 3}))
+	it := Reverse[int](counting)
+	equals(t, counting.Calls(), 0)
+	equals(t, it.Next(), Some(3))
+	equals(t, counting.Calls(), 4)
+	equals(t, it.Next(), Some(2))
+	equals(t, counting.Calls(), 4)
+}
@@ -0,0 +1,166 @@
+package iter
+
+import (
+	"context"
+	"sync"
+)
+
+type chanIter[T any] struct {
+	ch <-chan T
+}
+
+// Chan returns an Iterator that yields values received from a channel until
+// the channel is closed.
+func Chan[T any](ch <-chan T) Iterator[T] {
+	return &chanIter[T]{
+		ch: ch,
+	}
+}
+
+func (it *chanIter[T]) Next() Option[T] {
+	v, ok := <-it.ch
+	if !ok {
+		return None[T]()
+	}
+	return Some(v)
+}
+
+func (it *chanIter[T]) pull() (T, bool) {
+	v, ok := <-it.ch
+	return v, ok
+}
+
+func (it *chanIter[T]) drain(fn func(T)) {
+	for v := range it.ch {
+		fn(v)
+	}
+}
+
+// ChanRecv returns a function receiving the next value from a channel. Like a
+// two-value receive, the function reports false once the channel is closed and
+// keeps doing so on subsequent calls. Unlike Chan, values are not wrapped in
+// Options.
+func ChanRecv[T any](ch <-chan T) func() (T, bool) {
+	return func() (T, bool) {
+		v, ok := <-ch
+		return v, ok
+	}
+}
+
+// produce consumes the Iterator on a new goroutine sending the yielded values
+// to the returned channel with the given buffer size. The goroutine exits and
+// closes the channel once the Iterator is exhausted or done is closed.
+func produce[T any](it Iterator[T], size int, done <-chan struct{}) <-chan T {
+	ch := make(chan T, size)
+	next := pullFrom(it)
+	go func() {
+		defer close(ch)
+		for {
+			select {
+			case <-done:
+				return
+			default:
+			}
+			v, ok := next()
+			if !ok {
+				return
+			}
+			select {
+			case ch <- v:
+			case <-done:
+				return
+			}
+		}
+	}()
+	return ch
+}
+
+// ToChan consumes the Iterator on a new goroutine sending the yielded values to
+// the returned unbuffered channel. The channel is closed once the Iterator is
+// exhausted. Calling the returned cancel function stops the goroutine and closes
+// the channel; it must be called if the channel is abandoned before it is
+// closed.
+func ToChan[T any](it Iterator[T]) (<-chan T, func()) {
+	return ToChanBuffered(it, 0)
+}
+
+// ToChanBuffered is like ToChan but the returned channel has a buffer of the
+// given size.
+func ToChanBuffered[T any](it Iterator[T], size int) (<-chan T, func()) {
+	done := make(chan struct{})
+	var once sync.Once
+	return produce(it, size, done), func() {
+		once.Do(func() {
+			close(done)
+		})
+	}
+}
+
+// ToChanCtx consumes the Iterator on a new goroutine sending the yielded values
+// to the returned unbuffered channel. The goroutine exits and closes the channel
+// once the Iterator is exhausted or ctx is cancelled.
+func ToChanCtx[T any](ctx context.Context, it Iterator[T]) <-chan T {
+	return produce(it, 0, ctx.Done())
+}
+
+type bufferIter[T any] struct {
+	ch   <-chan T
+	done chan struct{}
+	once sync.Once
+}
+
+// Buffer returns an Iterator adapter that consumes the underlying Iterator on a
+// new goroutine, prefetching up to n elements ahead of the consumer into a
+// buffered channel. This allows a slow underlying Iterator and a slow consumer
+// to make progress at the same time. The returned Iterator implements Closer.
+func Buffer[T any](it Iterator[T], n uint) Iterator[T] {
+	done := make(chan struct{})
+	return &bufferIter[T]{
+		ch:   produce(it, int(n), done),
+		done: done,
+	}
+}
+
+func (it *bufferIter[T]) Next() Option[T] {
+	if closed(it.done) {
+		return None[T]()
+	}
+	select {
+	case v, ok := <-it.ch:
+		if !ok {
+			return None[T]()
+		}
+		return Some(v)
+	case <-it.done:
+		return None[T]()
+	}
+}
+
+func (it *bufferIter[T]) Close() {
+	it.once.Do(func() {
+		close(it.done)
+	})
+}
+
+// FanOut consumes the Iterator sending each yielded value to the channel
+// outs[route(v) % len(outs)]. Negative results of route wrap around. FanOut
+// returns nil once the Iterator is exhausted or ctx.Err() if ctx is cancelled
+// while FanOut is blocked on a send. The channels are not closed by FanOut.
+func FanOut[T any](ctx context.Context, it Iterator[T], outs []chan<- T, route func(T) int) error {
+	if len(outs) == 0 {
+		panic("FanOut requires at least one output channel.")
+	}
+	n := len(outs)
+	for ctx.Err() == nil {
+		v := it.Next()
+		if v.IsNone() {
+			return nil
+		}
+		value := v.Unwrap()
+		select {
+		case outs[(route(value)%n+n)%n] <- value:
+		case <-ctx.Done():
+		}
+	}
+	return ctx.Err()
+}
@@ -0,0 +1,246 @@
+package iter_test
+
+import (
+	"context"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	. "github.com/Soft/iter"
+	"github.com/Soft/iter/itertest"
+)
+
+func drainChan[T any](ch <-chan T) []T {
+	result := []T{}
+	for v := range ch {
+		result = append(result, v)
+	}
+	return result
+}
+
+func TestChan(t *testing.T) {
+	ch := make(chan int, 3)
+	ch <- 1
+	ch <- 2
+	ch <- 3
+	close(ch)
+	it := Chan(ch)
+	equals(t, it.Next().Unwrap(), 1)
+	equals(t, ToSlice(it), []int{2, 3})
+	equals(t, it.Next().IsNone(), true)
+	equals(t, it.Next().IsNone(), true)
+}
+
+func TestChanRecv(t *testing.T) {
+	ch := make(chan int, 3)
+	ch <- 1
+	ch <- 0
+	ch <- 3
+	close(ch)
+	recv := ChanRecv(ch)
+	var got []int
+	for v, ok := recv(); ok; v, ok = recv() {
+		got = append(got, v)
+	}
+	equals(t, got, []int{1, 0, 3})
+	v, ok := recv()
+	equals(t, v, 0)
+	equals(t, ok, false)
+}
+
+func TestToChan(t *testing.T) {
+	ch, cancel := ToChan(Range(0, 5, 1))
+	defer cancel()
+	equals(t, drainChan(ch), []int{0, 1, 2, 3, 4})
+	ch, cancel = ToChanBuffered(Range(0, 5, 1), 2)
+	defer cancel()
+	equals(t, drainChan(ch), []int{0, 1, 2, 3, 4})
+}
+
+func TestToChanCancel(t *testing.T) {
+	before := runtime.NumGoroutine()
+	ch, cancel := ToChanBuffered(Range(0, 100, 1), 4)
+	for i := 0; i < 50; i++ {
+		equals(t, <-ch, i)
+	}
+	cancel()
+	cancel()
+	waitGoroutines(t, before)
+	equals(t, len(drainChan(ch)) <= 5, true)
+}
+
+func TestToChanCtx(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	equals(t, drainChan(ToChanCtx(ctx, Range(0, 5, 1))), []int{0, 1, 2, 3, 4})
+}
+
+func TestToChanCtxCancel(t *testing.T) {
+	before := runtime.NumGoroutine()
+	ctx, cancel := context.WithCancel(context.Background())
+	ch := ToChanCtx(ctx, Repeat(1))
+	equals(t, <-ch, 1)
+	// The producer is now blocked sending the next value.
+	time.Sleep(10 * time.Millisecond)
+	cancel()
+	waitGoroutines(t, before)
+	equals(t, len(drainChan(ch)) <= 1, true)
+}
+
+func TestToChanCtxCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	pulled := false
+	ch := ToChanCtx(ctx, Func(func() Option[int] {
+		pulled = true
+		return Some(1)
+	}))
+	equals(t, drainChan(ch), []int{})
+	equals(t, pulled, false)
+}
+
+// benchmarkToChan moves elements through a channel of the given size between a
+// producer and a consumer that are both occasionally slow.
+func benchmarkToChan(b *testing.B, size int) {
+	for i := 0; i < b.N; i++ {
+		source := Map(Range(0, 100, 1), func(i int) int {
+			if i%10 < 5 {
+				time.Sleep(200 * time.Microsecond)
+			}
+			return i
+		})
+		ch, cancel := ToChanBuffered(source, size)
+		for v := range ch {
+			if v%10 >= 5 {
+				time.Sleep(200 * time.Microsecond)
+			}
+		}
+		cancel()
+	}
+}
+
+func BenchmarkToChan(b *testing.B) {
+	benchmarkToChan(b, 0)
+}
+
+func BenchmarkToChanBuffered(b *testing.B) {
+	benchmarkToChan(b, 16)
+}
+
+func TestFanOut(t *testing.T) {
+	chans := make([]chan int, 3)
+	outs := make([]chan<- int, len(chans))
+	for i := range chans {
+		chans[i] = make(chan int)
+		outs[i] = chans[i]
+	}
+	received := make([][]int, len(chans))
+	var wg sync.WaitGroup
+	for i, ch := range chans {
+		wg.Add(1)
+		go func(i int, ch chan int) {
+			defer wg.Done()
+			for v := range ch {
+				received[i] = append(received[i], v)
+			}
+		}(i, ch)
+	}
+	err := FanOut(context.Background(), Range(-10, 20, 1), outs, func(i int) int {
+		return i
+	})
+	equals(t, err, nil)
+	for _, ch := range chans {
+		close(ch)
+	}
+	wg.Wait()
+	for i, values := range received {
+		equals(t, len(values), 10)
+		equals(t, All(Slice(values), func(v int) bool {
+			return (v%3+3)%3 == i
+		}), true)
+	}
+}
+
+func TestFanOutCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	ch := make(chan int)
+	go func() {
+		<-ch
+		cancel()
+	}()
+	pulled := 0
+	source := Map(Range(0, 100, 1), func(i int) int {
+		pulled++
+		return i
+	})
+	err := FanOut(ctx, source, []chan<- int{ch}, func(int) int {
+		return 0
+	})
+	equals(t, err, context.Canceled)
+	equals(t, pulled <= 2, true)
+}
+
+func BenchmarkChan(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		ch, cancel := ToChanBuffered(Range(0, benchSize, 1), 64)
+		Count(Chan(ch))
+		cancel()
+	}
+}
+
+func BenchmarkChanNext(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		ch, cancel := ToChanBuffered(opaque(Range(0, benchSize, 1)), 64)
+		Count(opaque(Chan(ch)))
+		cancel()
+	}
+}
+
+func BenchmarkChanRecv(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		ch, cancel := ToChanBuffered(Range(0, benchSize, 1), 64)
+		recv := ChanRecv(ch)
+		for _, ok := recv(); ok; _, ok = recv() {
+		}
+		cancel()
+	}
+}
+
+func TestBuffer(t *testing.T) {
+	before := runtime.NumGoroutine()
+	itertest.AssertYields(t, Buffer(Range(0, 100, 1), 8), ToSlice(Range(0, 100, 1)))
+	itertest.AssertYields(t, Buffer(Empty[int](), 8), nil)
+	waitGoroutines(t, before)
+}
+
+func TestBufferPrefetch(t *testing.T) {
+	var pulled int32
+	source := Range(0, 100, 1)
+	it := Buffer(Func(func() Option[int] {
+		atomic.AddInt32(&pulled, 1)
+		return source.Next()
+	}), 4)
+	defer Close(it)
+	equals(t, it.Next(), Some(0))
+	// The producer fills the buffer and blocks holding one more element.
+	deadline := time.Now().Add(time.Second)
+	for atomic.LoadInt32(&pulled) < 6 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	time.Sleep(10 * time.Millisecond)
+	equals(t, atomic.LoadInt32(&pulled), int32(6))
+}
+
+func TestBufferClose(t *testing.T) {
+	before := runtime.NumGoroutine()
+	it := Buffer(Repeat(1), 4)
+	equals(t, ToSlice(Take(it, 3)), []int{1, 1, 1})
+	Close(it)
+	equals(t, it.Next().IsNone(), true)
+	waitGoroutines(t, before)
+}
@@ -0,0 +1,75 @@
+package iter
+
+// Cloneable[T] represents an Iterator whose state can be copied cheaply.
+type Cloneable[T any] interface {
+	Iterator[T]
+	// Clone returns an independent Iterator that yields the same elements as
+	// the remaining elements of this Iterator.
+	Clone() Iterator[T]
+}
+
+// cloner is implemented by Iterators that can be cloned only under some
+// conditions, such as adapters wrapping a Cloneable Iterator. clone reports
+// false if the Iterator cannot be cloned.
+type cloner[T any] interface {
+	clone() (Iterator[T], bool)
+}
+
+// Clone returns an independent copy of the Iterator if its state can be copied.
+// The copy yields the same elements as the remaining elements of the original
+// Iterator, and advancing either of them does not affect the other. Adapters
+// such as Map and Filter can be cloned if the underlying Iterator can; their
+// functions are shared between the copies and called again by each copy.
+// Adapters called for their side effects, such as Inspect, cannot be cloned.
+func Clone[T any](it Iterator[T]) Option[Iterator[T]] {
+	switch c := it.(type) {
+	case Cloneable[T]:
+		return Some(c.Clone())
+	case cloner[T]:
+		if clone, ok := c.clone(); ok {
+			return Some(clone)
+		}
+	}
+	return None[Iterator[T]]()
+}
+
+func (it *sliceIter[T]) Clone() Iterator[T] {
+	clone := *it
+	return &clone
+}
+
+func (it *rangeIter) Clone() Iterator[int] {
+	clone := *it
+	return &clone
+}
+
+func (it *repeatIter[T]) Clone() Iterator[T] {
+	clone := *it
+	return &clone
+}
+
+func (it *mapIter[T, R]) clone() (Iterator[R], bool) {
+	if it.effectful {
+		return nil, false
+	}
+	inner := Clone(it.inner)
+	if inner.IsNone() {
+		return nil, false
+	}
+	return Map(inner.Unwrap(), it.fn), true
+}
+
+func (it *filterIter[T]) clone() (Iterator[T], bool) {
+	if it.stateful {
+		return nil, false
+	}
+	inner := Clone(it.inner)
+	if inner.IsNone() {
+		return nil, false
+	}
+	return &filterIter[T]{
+		inner: inner.Unwrap(),
+		next:  pullFrom(inner.Unwrap()),
+		pred:  it.pred,
+	}, true
+}
@@ -0,0 +1,81 @@
+package iter_test
+
+import (
+	"testing"
+
+	. "github.com/Soft/iter"
+	"github.com/Soft/iter/itertest"
+)
+
+func TestClone(t *testing.T) {
+	sources := map[string]func() Iterator[int]{
+		"slice": func() Iterator[int] {
+			return Slice([]int{1, 2, 3, 4})
+		},
+		"range": func() Iterator[int] {
+			return Range(1, 5, 1)
+		},
+		"map": func() Iterator[int] {
+			return Map(Range(0, 4, 1), func(i int) int {
+				return i + 1
+			})
+		},
+		"filter": func() Iterator[int] {
+			return Filter(Range(1, 9, 1), func(i int) bool {
+				return i <= 4
+			})
+		},
+	}
+	for name, source := range sources {
+		t.Run(name, func(t *testing.T) {
+			it := source()
+			equals(t, it.Next(), Some(1))
+			clone := Clone(it).Unwrap()
+			equals(t, it.Next(), Some(2))
+			equals(t, it.Next(), Some(3))
+			equals(t, clone.Next(), Some(2))
+			itertest.AssertYields(t, it, []int{4})
+			itertest.AssertYields(t, clone, []int{3, 4})
+		})
+	}
+}
+
+func TestCloneRepeat(t *testing.T) {
+	it := Repeat(1)
+	clone := Clone(it).Unwrap()
+	equals(t, ToSlice(Take(clone, 2)), []int{1, 1})
+	equals(t, ToSlice(Take(it, 2)), []int{1, 1})
+}
+
+func TestCloneUnsupported(t *testing.T) {
+	equals(t, Clone(opaque(Range(0, 3, 1))).IsNone(), true)
+	equals(t, Clone(Map(opaque(Range(0, 3, 1)), square)).IsNone(), true)
+	equals(t, Clone(Unique(Range(0, 3, 1))).IsNone(), true)
+	equals(t, Clone(Inspect(Range(0, 3, 1), func(int) {})).IsNone(), true)
+	equals(t, Clone(Map(Inspect(Range(0, 3, 1), func(int) {}), square)).IsNone(), true)
+	equals(t, Clone(Filter(SkipEvery(Range(0, 3, 1), 2), isOdd)).IsNone(), true)
+}
+
+func TestCartesianProductClone(t *testing.T) {
+	calls := 0
+	b := Map(Slice([]string{"a", "b"}), func(s string) string {
+		calls++
+		return s
+	})
+	itertest.AssertYields(
+		t,
+		CartesianProduct[int, string](Range(0, 3, 1), b),
+		[]Pair[int, string]{{0, "a"}, {0, "b"}, {1, "a"}, {1, "b"}, {2, "a"}, {2, "b"}},
+	)
+	// The cloned Iterator is replayed instead of buffered, calling the
+	// function again for every replay.
+	equals(t, calls, 6)
+	calls = 0
+	inspected := Inspect(Slice([]string{"a", "b"}), func(string) {
+		calls++
+	})
+	equals(t, Count(CartesianProduct[int, string](Range(0, 3, 1), inspected)), uint(6))
+	// Inspect cannot be cloned so its elements are buffered.
+	equals(t, calls, 2)
+	itertest.AssertFused(t, CartesianProduct[int, int](Repeat(1), Range(0, 0, 1)))
+}
@@ -0,0 +1,40 @@
+// Package constraint defines type constraints for use with the generic
+// functions of package iter.
+package constraint
+
+// Signed is a constraint that permits any signed integer type.
+type Signed interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64
+}
+
+// Unsigned is a constraint that permits any unsigned integer type.
+type Unsigned interface {
+	~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
+}
+
+// Integer is a constraint that permits any integer type.
+type Integer interface {
+	Signed | Unsigned
+}
+
+// Float is a constraint that permits any floating-point type.
+type Float interface {
+	~float32 | ~float64
+}
+
+// Complex is a constraint that permits any complex numeric type.
+type Complex interface {
+	~complex64 | ~complex128
+}
+
+// Number is a constraint that permits any real numeric type. Values of these
+// types can be converted to float64.
+type Number interface {
+	Integer | Float
+}
+
+// Ordered is a constraint that permits any type supporting the <, <=, >= and >
+// operators.
+type Ordered interface {
+	Integer | Float | ~string
+}
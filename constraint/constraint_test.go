@@ -0,0 +1,59 @@
+package constraint
+
+import "testing"
+
+func negate[T Signed](v T) T {
+	return -v
+}
+
+func half[T Unsigned](v T) T {
+	return v >> 1
+}
+
+func mod[T Integer](a, b T) T {
+	return a % b
+}
+
+func ratio[T Float](a, b T) T {
+	return a / b
+}
+
+func square[T Complex](v T) T {
+	return v * v
+}
+
+func mean[T Number](a, b T) float64 {
+	return (float64(a) + float64(b)) / 2
+}
+
+func less[T Ordered](a, b T) bool {
+	return a < b
+}
+
+type celsius float64
+
+type name string
+
+func TestConstraints(t *testing.T) {
+	if negate(int8(3)) != -3 || negate(int64(3)) != -3 {
+		t.Fatal("Signed")
+	}
+	if half(uint8(7)) != 3 || half(uintptr(8)) != 4 {
+		t.Fatal("Unsigned")
+	}
+	if mod(7, 3) != 1 || mod(uint16(7), 4) != 3 {
+		t.Fatal("Integer")
+	}
+	if ratio(float32(1), 2) != 0.5 || ratio(celsius(3), 2) != 1.5 {
+		t.Fatal("Float")
+	}
+	if square(complex64(1+2i)) != -3+4i || square(complex128(2)) != 4 {
+		t.Fatal("Complex")
+	}
+	if mean(1, 2) != 1.5 || mean(uint8(255), 255) != 255 || mean(celsius(1), 2) != 1.5 {
+		t.Fatal("Number")
+	}
+	if !less(1, 2) || !less(1.5, 2.5) || !less[name]("a", "b") || less(uint(2), 1) {
+		t.Fatal("Ordered")
+	}
+}
@@ -0,0 +1,244 @@
+package iter
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/Soft/iter/constraint"
+)
+
+// ErrDuplicateKey is returned by ToMapUnique when two elements map to the same
+// key.
+var ErrDuplicateKey = errors.New("duplicate key")
+
+// Partition consumes an Iterator splitting its elements into two slices: the
+// ones for which pred predicate function returns true and the rest. The order
+// of the elements is preserved.
+func Partition[T any](it Iterator[T], pred func(T) bool) (matched []T, rest []T) {
+	matched, rest = []T{}, []T{}
+	ForEach(it, func(v T) {
+		if pred(v) {
+			matched = append(matched, v)
+		} else {
+			rest = append(rest, v)
+		}
+	})
+	return matched, rest
+}
+
+// GroupBy consumes an Iterator grouping its elements by the key returned by
+// function key. The elements in each group are in the order they were yielded.
+func GroupBy[T any, K comparable](it Iterator[T], key func(T) K) map[K][]T {
+	groups := make(map[K][]T)
+	ForEach(it, func(v T) {
+		k := key(v)
+		groups[k] = append(groups[k], v)
+	})
+	return groups
+}
+
+// Reduce consumes an Iterator combining its elements using function fn, with
+// the first element as the initial accumulator. Reduce returns None if the
+// Iterator is empty.
+func Reduce[T any](it Iterator[T], fn func(T, T) T) Option[T] {
+	acc := it.Next()
+	if acc.IsNone() {
+		return acc
+	}
+	return Some(Fold(it, acc.Unwrap(), fn))
+}
+
+// Min consumes an Iterator returning its smallest element. When multiple
+// elements are equally small, the first one is returned. Min returns None if the
+// Iterator is empty.
+func Min[T constraint.Ordered](it Iterator[T]) Option[T] {
+	var min T
+	found := false
+	ForEach(it, func(v T) {
+		if !found || v < min {
+			min = v
+			found = true
+		}
+	})
+	if !found {
+		return None[T]()
+	}
+	return Some(min)
+}
+
+// Max consumes an Iterator returning its largest element. When multiple
+// elements are equally large, the first one is returned. Max returns None if the
+// Iterator is empty.
+func Max[T constraint.Ordered](it Iterator[T]) Option[T] {
+	var max T
+	found := false
+	ForEach(it, func(v T) {
+		if !found || v > max {
+			max = v
+			found = true
+		}
+	})
+	if !found {
+		return None[T]()
+	}
+	return Some(max)
+}
+
+// bestBy consumes an Iterator returning the element whose key, as returned by
+// function key, is preferred over the keys of all the other elements according
+// to better. Function key is called once for each element.
+func bestBy[T any, K constraint.Ordered](it Iterator[T], key func(T) K, better func(K, K) bool) Option[T] {
+	var best T
+	var bestKey K
+	found := false
+	ForEach(it, func(v T) {
+		k := key(v)
+		if !found || better(k, bestKey) {
+			best, bestKey = v, k
+			found = true
+		}
+	})
+	if !found {
+		return None[T]()
+	}
+	return Some(best)
+}
+
+// MinBy consumes an Iterator returning the element with the smallest key, as
+// returned by function key. When multiple elements have equally small keys, the
+// first one is returned. MinBy returns None if the Iterator is empty.
+func MinBy[T any, K constraint.Ordered](it Iterator[T], key func(T) K) Option[T] {
+	return bestBy(it, key, func(a, b K) bool {
+		return a < b
+	})
+}
+
+// MaxBy consumes an Iterator returning the element with the largest key, as
+// returned by function key. When multiple elements have equally large keys, the
+// first one is returned. MaxBy returns None if the Iterator is empty.
+func MaxBy[T any, K constraint.Ordered](it Iterator[T], key func(T) K) Option[T] {
+	return bestBy(it, key, func(a, b K) bool {
+		return a > b
+	})
+}
+
+// Mean consumes an Iterator returning the arithmetic mean of its elements. The
+// elements are summed using compensated summation to limit the loss of
+// precision in long streams. Mean returns None if the Iterator is empty.
+func Mean[T constraint.Number](it Iterator[T]) Option[float64] {
+	var sum, compensation float64
+	var n uint
+	ForEach(it, func(v T) {
+		x := float64(v)
+		t := sum + x
+		// Neumaier's variant of Kahan summation.
+		if math.Abs(sum) >= math.Abs(x) {
+			compensation += (sum - t) + x
+		} else {
+			compensation += (x - t) + sum
+		}
+		sum = t
+		n++
+	})
+	if n == 0 {
+		return None[float64]()
+	}
+	return Some((sum + compensation) / float64(n))
+}
+
+// Contains determines if the Iterator yields an element equal to target. The
+// Iterator is consumed up to and including the first such element.
+func Contains[T comparable](it Iterator[T], target T) bool {
+	next := pullFrom(it)
+	for v, ok := next(); ok; v, ok = next() {
+		if v == target {
+			return true
+		}
+	}
+	return false
+}
+
+// Position returns the index of the first element of the Iterator that
+// satisfies pred predicate function. The Iterator is consumed up to and
+// including that element. Position returns None if no element satisfies pred.
+func Position[T any](it Iterator[T], pred func(T) bool) Option[uint] {
+	next := pullFrom(it)
+	var i uint
+	for v, ok := next(); ok; v, ok = next() {
+		if pred(v) {
+			return Some(i)
+		}
+		i++
+	}
+	return None[uint]()
+}
+
+// PositionLast consumes an Iterator returning the index of the last element
+// that satisfies pred predicate function. PositionLast returns None if no
+// element satisfies pred.
+func PositionLast[T any](it Iterator[T], pred func(T) bool) Option[uint] {
+	var i, last uint
+	found := false
+	ForEach(it, func(v T) {
+		if pred(v) {
+			last = i
+			found = true
+		}
+		i++
+	})
+	if !found {
+		return None[uint]()
+	}
+	return Some(last)
+}
+
+// Frequencies consumes an Iterator returning the number of times each distinct
+// element occurred.
+func Frequencies[T comparable](it Iterator[T]) map[T]uint {
+	counts := make(map[T]uint)
+	ForEach(it, func(v T) {
+		counts[v]++
+	})
+	return counts
+}
+
+// ToMap consumes an Iterator returning a map of its elements indexed by the key
+// returned by function key. Later elements replace earlier elements with the
+// same key.
+func ToMap[T any, K comparable](it Iterator[T], key func(T) K) map[K]T {
+	m := make(map[K]T)
+	ForEach(it, func(v T) {
+		m[key(v)] = v
+	})
+	return m
+}
+
+// ToMapUnique returns a map of the elements of an Iterator indexed by the key
+// returned by function key. If two elements map to the same key ToMapUnique
+// stops, leaving the rest of the Iterator unconsumed, and returns the elements
+// indexed so far along with an error wrapping ErrDuplicateKey.
+func ToMapUnique[T any, K comparable](it Iterator[T], key func(T) K) (map[K]T, error) {
+	m := make(map[K]T)
+	next := pullFrom(it)
+	for v, ok := next(); ok; v, ok = next() {
+		k := key(v)
+		if _, dup := m[k]; dup {
+			return m, fmt.Errorf("%w: %v", ErrDuplicateKey, k)
+		}
+		m[k] = v
+	}
+	return m, nil
+}
+
+// Associate consumes an Iterator returning a map built from the key-value pairs
+// returned by function fn for each element. Later pairs replace earlier pairs
+// with the same key.
+func Associate[T any, K comparable, V any](it Iterator[T], fn func(T) (K, V)) map[K]V {
+	m := make(map[K]V)
+	ForEach(it, func(elem T) {
+		k, v := fn(elem)
+		m[k] = v
+	})
+	return m
+}
@@ -0,0 +1,204 @@
+package iter_test
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	. "github.com/Soft/iter"
+	"github.com/Soft/iter/itertest"
+)
+
+func TestPartition(t *testing.T) {
+	ch := make(chan int, 6)
+	for i := 0; i < 6; i++ {
+		ch <- i
+	}
+	close(ch)
+	odd, even := Partition(Chan(ch), isOdd)
+	equals(t, odd, []int{1, 3, 5})
+	equals(t, even, []int{0, 2, 4})
+	odd, even = Partition(Empty[int](), isOdd)
+	equals(t, odd, []int{})
+	equals(t, even, []int{})
+	odd, even = Partition(Slice([]int{1, 3}), isOdd)
+	equals(t, odd, []int{1, 3})
+	equals(t, even, []int{})
+}
+
+func TestGroupBy(t *testing.T) {
+	firstRune := func(s string) rune {
+		return String(s).Next().Unwrap()
+	}
+	equals(
+		t,
+		GroupBy(Slice([]string{"apple", "äiti", "avocado", "banana", "äes", "blueberry", "cherry"}), firstRune),
+		map[rune][]string{
+			'a': {"apple", "avocado"},
+			'ä': {"äiti", "äes"},
+			'b': {"banana", "blueberry"},
+			'c': {"cherry"},
+		},
+	)
+	ch := make(chan int, 5)
+	for i := 0; i < 5; i++ {
+		ch <- i
+	}
+	close(ch)
+	equals(t, GroupBy(Chan(ch), isOdd), map[bool][]int{false: {0, 2, 4}, true: {1, 3}})
+	equals(t, GroupBy(Empty[int](), isOdd), map[bool][]int{})
+}
+
+func TestReduce(t *testing.T) {
+	calls := 0
+	add := func(a, b int) int {
+		calls++
+		return a + b
+	}
+	equals(t, Reduce(Empty[int](), add), None[int]())
+	equals(t, Reduce(Slice([]int{5}), add), Some(5))
+	equals(t, calls, 0)
+	equals(t, Reduce(Range(1, 5, 1), add), Some(10))
+	equals(t, calls, 3)
+	equals(t, Reduce(Slice([]string{"a", "b", "c"}), func(a, b string) string {
+		return b + a
+	}), Some("cba"))
+}
+
+func TestMin(t *testing.T) {
+	equals(t, Min(Slice([]int{3, 1, 4, 1, 5})), Some(1))
+	equals(t, Min(Slice([]int{0, -2, 7})), Some(-2))
+	equals(t, Min(Slice([]string{"pear", "apple", "banana"})), Some("apple"))
+	equals(t, Min(Slice([]float64{2.5})), Some(2.5))
+	equals(t, Min(Empty[int]()), None[int]())
+}
+
+func TestMax(t *testing.T) {
+	equals(t, Max(Slice([]int{3, 1, 4, 1, 5})), Some(5))
+	equals(t, Max(Slice([]int{-3, -1, -2})), Some(-1))
+	equals(t, Max(Slice([]string{"pear", "apple", "banana"})), Some("pear"))
+	equals(t, Max(Empty[int]()), None[int]())
+	// Negative and positive zero are equal, so the first one wins.
+	equals(t, math.Signbit(Max(Slice([]float64{math.Copysign(0, -1), 0})).Unwrap()), true)
+	equals(t, math.Signbit(Max(Slice([]float64{0, math.Copysign(0, -1)})).Unwrap()), false)
+}
+
+func TestMinByMaxBy(t *testing.T) {
+	calls := 0
+	length := func(s string) int {
+		calls++
+		return len(s)
+	}
+	words := []string{"fig", "banana", "kiwi", "cherry", "plum"}
+	equals(t, MaxBy(Slice(words), length), Some("banana"))
+	equals(t, calls, len(words))
+	equals(t, MinBy(Slice(words), length), Some("fig"))
+	equals(t, MinBy(Slice([]string{"kiwi", "plum"}), length), Some("kiwi"))
+	equals(t, MaxBy(Empty[string](), length), None[string]())
+	equals(t, MinBy(Empty[string](), length), None[string]())
+}
+
+func TestMean(t *testing.T) {
+	equals(t, Mean(Range(1, 5, 1)), Some(2.5))
+	equals(t, Mean(Slice([]float64{0.5, 1.5, -1})), Some(1.0/3))
+	equals(t, Mean(Slice([]uint8{255, 255})), Some(255.0))
+	equals(t, Mean(Empty[int]()), None[float64]())
+	// Summing naively loses every 1 added to 1e16.
+	values := Chain(Once(1e16), RepeatN(1.0, 1000))
+	naive := Fold(Chain(Once(1e16), RepeatN(1.0, 1000)), 0.0, func(acc, v float64) float64 {
+		return acc + v
+	}) / 1001
+	want := (1e16 + 1000) / 1001
+	mean := Mean(values).Unwrap()
+	equals(t, math.Abs(mean-want) < 0.01, true)
+	equals(t, math.Abs(naive-want) > 0.5, true)
+}
+
+func TestContains(t *testing.T) {
+	equals(t, Contains(Range(0, 10, 1), 7), true)
+	equals(t, Contains(Range(0, 10, 1), 10), false)
+	equals(t, Contains(Slice([]string{"a", "b"}), "b"), true)
+	equals(t, Contains(Empty[int](), 0), false)
+	counting := itertest.Counting(Range(0, 10, 1))
+	equals(t, Contains[int](counting, 0), true)
+	equals(t, counting.Calls(), 1)
+}
+
+func TestPosition(t *testing.T) {
+	equals(t, Position(Slice([]int{2, 3, 5, 7}), isOdd), Some[uint](1))
+	equals(t, Position(Slice([]int{3, 2}), isOdd), Some[uint](0))
+	equals(t, Position(Slice([]int{2, 4}), isOdd), None[uint]())
+	counting := itertest.Counting(Range(0, 10, 1))
+	equals(t, Position[int](counting, isOdd), Some[uint](1))
+	equals(t, counting.Calls(), 2)
+}
+
+func TestPositionLast(t *testing.T) {
+	equals(t, PositionLast(Slice([]int{2, 3, 5, 8}), isOdd), Some[uint](2))
+	equals(t, PositionLast(Slice([]int{3, 2, 4}), isOdd), Some[uint](0))
+	equals(t, PositionLast(Slice([]int{2, 4, 5}), isOdd), Some[uint](2))
+	equals(t, PositionLast(Slice([]int{2, 4}), isOdd), None[uint]())
+	equals(t, PositionLast(Empty[int](), isOdd), None[uint]())
+}
+
+func TestFrequencies(t *testing.T) {
+	equals(t, Frequencies(String("a banana")), map[rune]uint{
+		'a': 4,
+		'b': 1,
+		'n': 2,
+		' ': 1,
+	})
+	equals(t, Frequencies(Empty[int]()), map[int]uint{})
+}
+
+func TestToMap(t *testing.T) {
+	words := []string{"apple", "bean", "avocado", "cherry"}
+	first := func(s string) byte {
+		return s[0]
+	}
+	equals(t, ToMap(Slice(words), first), map[byte]string{
+		'a': "avocado",
+		'b': "bean",
+		'c': "cherry",
+	})
+	equals(t, ToMap(Empty[string](), first), map[byte]string{})
+}
+
+func TestToMapUnique(t *testing.T) {
+	first := func(s string) byte {
+		return s[0]
+	}
+	m, err := ToMapUnique(Slice([]string{"apple", "bean"}), first)
+	equals(t, err, nil)
+	equals(t, m, map[byte]string{'a': "apple", 'b': "bean"})
+	it := Slice([]string{"apple", "bean", "avocado", "cherry"})
+	m, err = ToMapUnique(it, first)
+	equals(t, errors.Is(err, ErrDuplicateKey), true)
+	equals(t, err.Error(), "duplicate key: 97")
+	equals(t, m, map[byte]string{'a': "apple", 'b': "bean"})
+	itertest.AssertYields(t, it, []string{"cherry"})
+	m, err = ToMapUnique(Empty[string](), first)
+	equals(t, err, nil)
+	equals(t, m, map[byte]string{})
+}
+
+func TestAssociate(t *testing.T) {
+	type user struct {
+		ID    int
+		Name  string
+		Email string
+		Admin bool
+	}
+	users := []user{
+		{1, "alice", "alice@example.com", true},
+		{2, "bob", "bob@example.com", false},
+		{1, "alicia", "alicia@example.com", false},
+	}
+	names := Associate(Slice(users), func(u user) (int, string) {
+		return u.ID, u.Name
+	})
+	equals(t, names, map[int]string{1: "alicia", 2: "bob"})
+	equals(t, Associate(Empty[user](), func(u user) (int, string) {
+		return u.ID, u.Name
+	}), map[int]string{})
+}
@@ -0,0 +1,100 @@
+package iter
+
+import "reflect"
+
+type fieldsIter struct {
+	value  reflect.Value
+	fields []reflect.StructField
+}
+
+// fields returns an Iterator yielding the names and values of the exported
+// fields of a struct following the rules of FieldsOf.
+func fields(v any) Iterator[Pair[string, reflect.Value]] {
+	value := reflect.ValueOf(v)
+	if value.Kind() == reflect.Pointer {
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Struct {
+		panic("FieldsOf requires a struct or a pointer to a struct.")
+	}
+	// VisibleFields resolves promotion the way selectors do: shadowed and
+	// ambiguous fields are left out and each embedded type is only visited
+	// once, so recursive embedding terminates.
+	return &fieldsIter{
+		value:  value,
+		fields: reflect.VisibleFields(value.Type()),
+	}
+}
+
+func (it *fieldsIter) Next() Option[Pair[string, reflect.Value]] {
+	for len(it.fields) > 0 {
+		field := it.fields[0]
+		it.fields = it.fields[1:]
+		if !field.IsExported() || field.Anonymous && isStruct(field.Type) {
+			continue
+		}
+		if !exportedPath(it.value.Type(), field.Index) {
+			continue
+		}
+		value, err := it.value.FieldByIndexErr(field.Index)
+		if err != nil {
+			// The field is reached through a nil embedded pointer.
+			continue
+		}
+		return Some(Pair[string, reflect.Value]{First: field.Name, Second: value})
+	}
+	return None[Pair[string, reflect.Value]]()
+}
+
+// exportedPath reports whether the embedded fields leading to the field at
+// index in struct type t are all exported.
+func exportedPath(t reflect.Type, index []int) bool {
+	for _, i := range index[:len(index)-1] {
+		field := t.Field(i)
+		if !field.IsExported() {
+			return false
+		}
+		t = field.Type
+		if t.Kind() == reflect.Pointer {
+			t = t.Elem()
+		}
+	}
+	return true
+}
+
+// isStruct reports whether t is a struct or a pointer to a struct.
+func isStruct(t reflect.Type) bool {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t.Kind() == reflect.Struct
+}
+
+// FieldsOf returns an Iterator yielding the names and values of the exported
+// fields of a struct in declaration order. v must be a struct or a pointer to a
+// struct. The fields of embedded structs are yielded in place of the embedded
+// field following the promotion rules of selectors: fields shadowed by a
+// shallower field of the same name and ambiguous fields are skipped. Fields
+// reached through nil embedded pointers or embedded structs of unexported types
+// and unexported fields are skipped as well.
+func FieldsOf(v any) Iterator[Pair[string, any]] {
+	return Map(fields(v), func(p Pair[string, reflect.Value]) Pair[string, any] {
+		return Pair[string, any]{First: p.First, Second: p.Second.Interface()}
+	})
+}
+
+// FieldsOfAs is like FieldsOf but only yields the fields whose declared types
+// are assignable to T.
+func FieldsOfAs[T any](v any) Iterator[Pair[string, T]] {
+	target := reflect.TypeOf((*T)(nil)).Elem()
+	return Map(
+		Filter(fields(v), func(p Pair[string, reflect.Value]) bool {
+			return p.Second.Type().AssignableTo(target)
+		}),
+		func(p Pair[string, reflect.Value]) Pair[string, T] {
+			var value T
+			reflect.ValueOf(&value).Elem().Set(p.Second)
+			return Pair[string, T]{First: p.First, Second: value}
+		},
+	)
+}
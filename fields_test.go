@@ -0,0 +1,116 @@
+package iter_test
+
+import (
+	"fmt"
+	"testing"
+
+	. "github.com/Soft/iter"
+	"github.com/Soft/iter/itertest"
+)
+
+type Base struct {
+	ID   int
+	note string
+}
+
+type Meta struct {
+	Tags []string
+}
+
+type hidden struct {
+	Secret string
+}
+
+type Record struct {
+	Name string
+	Base
+	*Meta
+	hidden
+	Err   error
+	count int
+	Label fmt.Stringer
+}
+
+func TestFieldsOf(t *testing.T) {
+	r := Record{
+		Name:   "a",
+		Base:   Base{ID: 1, note: "x"},
+		Meta:   &Meta{Tags: []string{"t"}},
+		hidden: hidden{Secret: "s"},
+	}
+	want := []Pair[string, any]{
+		{"Name", "a"},
+		{"ID", 1},
+		{"Tags", []string{"t"}},
+		{"Err", nil},
+		{"Label", nil},
+	}
+	itertest.AssertYields(t, FieldsOf(r), want)
+	itertest.AssertYields(t, FieldsOf(&r), want)
+	r.Meta = nil
+	itertest.AssertYields(t, FieldsOf(r), []Pair[string, any]{
+		{"Name", "a"},
+		{"ID", 1},
+		{"Err", nil},
+		{"Label", nil},
+	})
+	itertest.AssertYields(t, FieldsOf(struct{}{}), nil)
+}
+
+type Shadowing struct {
+	ID int
+	Base
+}
+
+type Other struct {
+	ID   int
+	Kind string
+}
+
+type Ambiguous struct {
+	Base
+	Other
+}
+
+type Node struct {
+	*Node
+	Value int
+}
+
+func TestFieldsOfShadowing(t *testing.T) {
+	itertest.AssertYields(t, FieldsOf(Shadowing{ID: 1, Base: Base{ID: 2}}), []Pair[string, any]{
+		{"ID", 1},
+	})
+	itertest.AssertYields(t, FieldsOf(Ambiguous{Other: Other{Kind: "k"}}), []Pair[string, any]{
+		{"Kind", "k"},
+	})
+}
+
+func TestFieldsOfCycle(t *testing.T) {
+	n := &Node{Value: 1}
+	n.Node = n
+	itertest.AssertYields(t, FieldsOf(n), []Pair[string, any]{
+		{"Value", 1},
+	})
+}
+
+func TestFieldsOfPanics(t *testing.T) {
+	defer func() {
+		equals(t, recover() != nil, true)
+	}()
+	FieldsOf(1)
+}
+
+func TestFieldsOfAs(t *testing.T) {
+	r := Record{Name: "a", Base: Base{ID: 1}}
+	itertest.AssertYields(t, FieldsOfAs[string](r), []Pair[string, string]{
+		{"Name", "a"},
+	})
+	itertest.AssertYields(t, FieldsOfAs[int](&r), []Pair[string, int]{
+		{"ID", 1},
+	})
+	itertest.AssertYields(t, FieldsOfAs[error](r), []Pair[string, error]{
+		{"Err", nil},
+	})
+	equals(t, Count(FieldsOfAs[any](r)), uint(4))
+}
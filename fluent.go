@@ -0,0 +1,95 @@
+package iter
+
+// Iter[T] wraps an Iterator providing methods for chaining adapters and
+// consumers. Go does not allow methods to introduce type parameters, so
+// adapters that change the element type, such as Map, are only available as
+// functions.
+type Iter[T any] struct {
+	Iterator[T]
+}
+
+// From wraps an Iterator into Iter[T].
+func From[T any](it Iterator[T]) Iter[T] {
+	return Iter[T]{it}
+}
+
+// Filter is the method form of Filter.
+func (it Iter[T]) Filter(pred func(T) bool) Iter[T] {
+	return From(Filter(it.Iterator, pred))
+}
+
+// Take is the method form of Take.
+func (it Iter[T]) Take(n uint) Iter[T] {
+	return From(Take(it.Iterator, n))
+}
+
+// TakeWhile is the method form of TakeWhile.
+func (it Iter[T]) TakeWhile(pred func(T) bool) Iter[T] {
+	return From(TakeWhile(it.Iterator, pred))
+}
+
+// Drop is the method form of Drop.
+func (it Iter[T]) Drop(n uint) Iter[T] {
+	return From(Drop(it.Iterator, n))
+}
+
+// DropWhile is the method form of DropWhile.
+func (it Iter[T]) DropWhile(pred func(T) bool) Iter[T] {
+	return From(DropWhile(it.Iterator, pred))
+}
+
+// StepBy is the method form of StepBy.
+func (it Iter[T]) StepBy(n uint) Iter[T] {
+	return From(StepBy(it.Iterator, n))
+}
+
+// Chain is the method form of Chain.
+func (it Iter[T]) Chain(other Iterator[T]) Iter[T] {
+	return From(Chain(it.Iterator, other))
+}
+
+// Fuse is the method form of Fuse.
+func (it Iter[T]) Fuse() Iter[T] {
+	return From(Fuse(it.Iterator))
+}
+
+// Inspect is the method form of Inspect.
+func (it Iter[T]) Inspect(fn func(T)) Iter[T] {
+	return From(Inspect(it.Iterator, fn))
+}
+
+// ToSlice is the method form of ToSlice.
+func (it Iter[T]) ToSlice() []T {
+	return ToSlice(it.Iterator)
+}
+
+// ForEach is the method form of ForEach.
+func (it Iter[T]) ForEach(fn func(T)) {
+	ForEach(it.Iterator, fn)
+}
+
+// Fold is the method form of Fold. The accumulator is untyped since methods
+// cannot introduce type parameters.
+func (it Iter[T]) Fold(init any, fn func(any, T) any) any {
+	return Fold(it.Iterator, init, fn)
+}
+
+// Count is the method form of Count.
+func (it Iter[T]) Count() uint {
+	return Count(it.Iterator)
+}
+
+// Find is the method form of Find.
+func (it Iter[T]) Find(pred func(T) bool) Option[T] {
+	return Find(it.Iterator, pred)
+}
+
+// All is the method form of All.
+func (it Iter[T]) All(pred func(T) bool) bool {
+	return All(it.Iterator, pred)
+}
+
+// Any is the method form of Any.
+func (it Iter[T]) Any(pred func(T) bool) bool {
+	return Any(it.Iterator, pred)
+}
@@ -0,0 +1,65 @@
+package iter_test
+
+import (
+	"testing"
+
+	. "github.com/Soft/iter"
+)
+
+func TestFluent(t *testing.T) {
+	lessThan4 := func(i int) bool {
+		return i < 4
+	}
+	equals(
+		t,
+		From(Slice([]int{1, 2, 3, 4, 5})).DropWhile(lessThan4).ToSlice(),
+		ToSlice(DropWhile(Slice([]int{1, 2, 3, 4, 5}), lessThan4)),
+	)
+	equals(
+		t,
+		From(Slice([]int{1, 2, 3, 4, 5})).TakeWhile(lessThan4).ToSlice(),
+		[]int{1, 2, 3},
+	)
+	equals(
+		t,
+		From(Repeat(5)).Take(3).Chain(Slice([]int{1, 2})).ToSlice(),
+		ToSlice(Chain(Take(Repeat(5), 3), Slice([]int{1, 2}))),
+	)
+	equals(
+		t,
+		From(Range(0, 10, 1)).Filter(isOdd).Drop(1).Fuse().Count(),
+		uint(4),
+	)
+	equals(
+		t,
+		From(Slice([]int{1, 2, 3, 4, 5})).Fold(0, func(acc any, i int) any {
+			return acc.(int) + i
+		}),
+		any(15),
+	)
+	equals(t, From(Slice([]int{1, 2, 3, 4, 5})).Find(func(i int) bool {
+		return i > 3
+	}), Some(4))
+	equals(t, From(Range(5, 10, 1)).All(func(n int) bool {
+		return n > 4
+	}), true)
+	equals(t, From(Range(5, 10, 1)).Any(func(n int) bool {
+		return n > 9
+	}), false)
+	equals(t, From(Range(0, 10, 1)).StepBy(3).ToSlice(), []int{0, 3, 6, 9})
+	var ret int
+	From(Take(Repeat(1), 5)).ForEach(func(i int) {
+		ret += i
+	})
+	equals(t, ret, 5)
+	equals(t, ToSlice[int](From(Range(0, 3, 1))), []int{0, 1, 2})
+}
+
+func TestFluentInspect(t *testing.T) {
+	var seen []int
+	got := From(Range(0, 10, 1)).Filter(isOdd).Inspect(func(i int) {
+		seen = append(seen, i)
+	}).Take(2).ToSlice()
+	equals(t, got, []int{1, 3})
+	equals(t, seen, []int{1, 3})
+}
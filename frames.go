@@ -0,0 +1,86 @@
+package iter
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"io"
+)
+
+// ErrFrameTooLarge is yielded by Frames when a length prefix exceeds the
+// maximum frame size.
+var ErrFrameTooLarge = errors.New("frame exceeds maximum size")
+
+type framesIter struct {
+	r       io.Reader
+	length  func() (uint64, error)
+	maxSize uint32
+	done    bool
+}
+
+// Frames returns an Iterator yielding the payloads of frames read from r. Each
+// frame consists of a uvarint length prefix followed by that many bytes. Frames
+// longer than maxSize yield ErrFrameTooLarge and truncated frames yield
+// io.ErrUnexpectedEOF. The Iterator keeps yielding None after an error. If r
+// does not implement io.ByteReader it is buffered and may be read past the
+// last frame.
+func Frames(r io.Reader, maxSize uint32) Iterator[Result[[]byte]] {
+	br, ok := r.(io.ByteReader)
+	if !ok {
+		buffered := bufio.NewReader(r)
+		r, br = buffered, buffered
+	}
+	return &framesIter{
+		r: r,
+		length: func() (uint64, error) {
+			return binary.ReadUvarint(br)
+		},
+		maxSize: maxSize,
+	}
+}
+
+// FramesUint32 is like Frames but each frame is prefixed by its length as a
+// big-endian uint32.
+func FramesUint32(r io.Reader, maxSize uint32) Iterator[Result[[]byte]] {
+	return &framesIter{
+		r: r,
+		length: func() (uint64, error) {
+			var prefix [4]byte
+			if _, err := io.ReadFull(r, prefix[:]); err != nil {
+				return 0, err
+			}
+			return uint64(binary.BigEndian.Uint32(prefix[:])), nil
+		},
+		maxSize: maxSize,
+	}
+}
+
+func (it *framesIter) Next() Option[Result[[]byte]] {
+	if it.done {
+		return None[Result[[]byte]]()
+	}
+	length, err := it.length()
+	if err == io.EOF {
+		it.done = true
+		return None[Result[[]byte]]()
+	}
+	if err == nil && length > uint64(it.maxSize) {
+		err = ErrFrameTooLarge
+	}
+	if err != nil {
+		return it.fail(err)
+	}
+	payload := make([]byte, length)
+	if _, err := io.ReadFull(it.r, payload); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return it.fail(err)
+	}
+	return Some(Ok(payload))
+}
+
+func (it *framesIter) fail(err error) Option[Result[[]byte]] {
+	it.done = true
+	return Some(Err[[]byte](err))
+}
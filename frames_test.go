@@ -0,0 +1,73 @@
+package iter_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"testing"
+
+	. "github.com/Soft/iter"
+)
+
+func uvarintFrames(payloads ...string) []byte {
+	var buf bytes.Buffer
+	for _, p := range payloads {
+		var prefix [binary.MaxVarintLen64]byte
+		buf.Write(prefix[:binary.PutUvarint(prefix[:], uint64(len(p)))])
+		buf.WriteString(p)
+	}
+	return buf.Bytes()
+}
+
+func uint32Frames(payloads ...string) []byte {
+	var buf bytes.Buffer
+	for _, p := range payloads {
+		binary.Write(&buf, binary.BigEndian, uint32(len(p)))
+		buf.WriteString(p)
+	}
+	return buf.Bytes()
+}
+
+// onlyReader hides all methods of a Reader except Read.
+type onlyReader struct {
+	io.Reader
+}
+
+func TestFrames(t *testing.T) {
+	sources := map[string]Iterator[Result[[]byte]]{
+		"uvarint":  Frames(bytes.NewReader(uvarintFrames("hello", "", "world")), 16),
+		"buffered": Frames(onlyReader{bytes.NewReader(uvarintFrames("hello", "", "world"))}, 16),
+		"uint32":   FramesUint32(bytes.NewReader(uint32Frames("hello", "", "world")), 16),
+	}
+	for name, it := range sources {
+		t.Run(name, func(t *testing.T) {
+			equals(t, it.Next().Unwrap().Unwrap(), []byte("hello"))
+			equals(t, it.Next().Unwrap().Unwrap(), []byte{})
+			equals(t, it.Next().Unwrap().Unwrap(), []byte("world"))
+			equals(t, it.Next().IsNone(), true)
+		})
+	}
+}
+
+func TestFramesTooLarge(t *testing.T) {
+	it := Frames(bytes.NewReader(uvarintFrames("ok", "too large", "ok")), 4)
+	equals(t, it.Next().Unwrap().Unwrap(), []byte("ok"))
+	equals(t, it.Next().Unwrap().Err(), ErrFrameTooLarge)
+	equals(t, it.Next().IsNone(), true)
+	it = FramesUint32(bytes.NewReader(uint32Frames("too large")), 4)
+	equals(t, it.Next().Unwrap().Err(), ErrFrameTooLarge)
+	equals(t, it.Next().IsNone(), true)
+}
+
+func TestFramesTruncated(t *testing.T) {
+	data := uvarintFrames("hello", "world")
+	it := Frames(bytes.NewReader(data[:len(data)-2]), 16)
+	equals(t, it.Next().Unwrap().Unwrap(), []byte("hello"))
+	equals(t, it.Next().Unwrap().Err(), io.ErrUnexpectedEOF)
+	equals(t, it.Next().IsNone(), true)
+	data = uint32Frames("hello", "world")
+	it = FramesUint32(bytes.NewReader(data[:len(data)-7]), 16)
+	equals(t, it.Next().Unwrap().Unwrap(), []byte("hello"))
+	equals(t, it.Next().Unwrap().Err(), io.ErrUnexpectedEOF)
+	equals(t, it.Next().IsNone(), true)
+}
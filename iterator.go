@@ -1,6 +1,9 @@
 package iter
 
-import "unicode/utf8"
+import (
+	"strings"
+	"unicode/utf8"
+)
 
 // Iterator[T] represents an iterator yielding elements of type T.
 type Iterator[T any] interface {
@@ -8,6 +11,62 @@ type Iterator[T any] interface {
 	Next() Option[T]
 }
 
+// ExactSize is implemented by Iterators that know how many elements they have
+// left to yield.
+type ExactSize interface {
+	// Len returns the number of elements remaining in the Iterator.
+	Len() uint
+}
+
+// sizer is implemented by Iterators that know their remaining length only under
+// some conditions, such as adapters wrapping an Iterator of known length.
+type sizer interface {
+	size() Option[uint]
+}
+
+// Len returns the number of elements remaining in the Iterator if the Iterator
+// knows it without being consumed.
+func Len[T any](it Iterator[T]) Option[uint] {
+	switch s := it.(type) {
+	case ExactSize:
+		return Some(s.Len())
+	case sizer:
+		return s.size()
+	}
+	return None[uint]()
+}
+
+// drainer is implemented by sources that can hand their remaining elements to
+// a function without going through Next. Consumers use it to avoid the
+// overhead of wrapping every element in an Option.
+type drainer[T any] interface {
+	drain(fn func(T))
+}
+
+// puller is implemented by Iterators that can yield their next element without
+// wrapping it in an Option. Map and Filter pull from their inner Iterator
+// through it so that a chain of adapters constructs a single Option per element
+// instead of one per adapter.
+type puller[T any] interface {
+	pull() (T, bool)
+}
+
+// pullFrom returns a function yielding the elements of it, bypassing Next if
+// it implements puller.
+func pullFrom[T any](it Iterator[T]) func() (T, bool) {
+	if p, ok := it.(puller[T]); ok {
+		return p.pull
+	}
+	return func() (T, bool) {
+		v := it.Next()
+		if v.IsNone() {
+			var zero T
+			return zero, false
+		}
+		return v.Unwrap(), true
+	}
+}
+
 type stringIter struct {
 	input string
 }
@@ -20,41 +79,124 @@ func String(input string) Iterator[rune] {
 }
 
 func (it *stringIter) Next() Option[rune] {
-	if len(it.input) == 0 {
+	value, ok := it.pull()
+	if !ok {
 		return None[rune]()
 	}
+	return Some(value)
+}
+
+func (it *stringIter) pull() (rune, bool) {
+	if len(it.input) == 0 {
+		return 0, false
+	}
 	value, width := utf8.DecodeRuneInString(it.input)
 	it.input = it.input[width:]
-	return Some(value)
+	return value, true
+}
+
+func (it *stringIter) drain(fn func(rune)) {
+	for value, ok := it.pull(); ok; value, ok = it.pull() {
+		fn(value)
+	}
 }
 
 type rangeIter struct {
-	start, stop, step, i int
+	next, stop, step int
+	inclusive, done  bool
 }
 
 // Range returns an Iterator over a range of integers.
 func Range(start, stop, step int) Iterator[int] {
 	return &rangeIter{
-		start: start,
-		stop:  stop,
-		step:  step,
-		i:     0,
+		next: start,
+		stop: stop,
+		step: step,
+	}
+}
+
+// RangeInclusive returns an Iterator over a range of integers that includes end
+// if it is reached by stepping from start. Otherwise, the last value yielded is
+// the last one before end. A zero step behaves as it does with Range.
+func RangeInclusive(start, end, step int) Iterator[int] {
+	return &rangeIter{
+		next:      start,
+		stop:      end,
+		step:      step,
+		inclusive: step != 0,
 	}
 }
 
 func (it *rangeIter) Next() Option[int] {
-	v := it.start + it.step*it.i
+	v, ok := it.pull()
+	if !ok {
+		return None[int]()
+	}
+	return Some(v)
+}
+
+// distance returns the distance between the next value and the end of the range
+// and the magnitude of the step. Differences are computed in uint64 to avoid
+// overflowing int.
+func (it *rangeIter) distance() (uint64, uint64) {
 	if it.step > 0 {
-		if v >= it.stop {
-			return None[int]()
+		return uint64(it.stop) - uint64(it.next), uint64(it.step)
+	}
+	return uint64(it.next) - uint64(it.stop), uint64(0) - uint64(it.step)
+}
+
+func (it *rangeIter) remaining() bool {
+	if it.done {
+		return false
+	}
+	if it.inclusive && it.next == it.stop {
+		return true
+	}
+	if it.step > 0 {
+		return it.next < it.stop
+	}
+	return it.next > it.stop
+}
+
+func (it *rangeIter) pull() (int, bool) {
+	if !it.remaining() {
+		return 0, false
+	}
+	v := it.next
+	if it.step != 0 {
+		if d, step := it.distance(); d < step || d == step && !it.inclusive {
+			it.done = true
+		} else {
+			it.next += it.step
 		}
-	} else {
-		if v <= it.stop {
-			return None[int]()
+	}
+	return v, true
+}
+
+func (it *rangeIter) drain(fn func(int)) {
+	for v, ok := it.pull(); ok; v, ok = it.pull() {
+		fn(v)
+	}
+}
+
+func (it *rangeIter) size() Option[uint] {
+	switch {
+	case !it.remaining():
+		return Some[uint](0)
+	case it.step == 0:
+		// Zero step ranges are infinite.
+		return None[uint]()
+	}
+	d, step := it.distance()
+	if it.inclusive {
+		n := d / step
+		if n >= uint64(^uint(0)) {
+			// The length does not fit in uint.
+			return None[uint]()
 		}
+		return Some(uint(n) + 1)
 	}
-	it.i++
-	return Some(v)
+	return Some(uint((d-1)/step + 1))
 }
 
 type sliceIter[T any] struct {
@@ -77,61 +219,197 @@ func (it *sliceIter[T]) Next() Option[T] {
 	return Some[T](first)
 }
 
+func (it *sliceIter[T]) Len() uint {
+	return uint(len(it.slice))
+}
+
+func (it *sliceIter[T]) pull() (T, bool) {
+	if len(it.slice) == 0 {
+		var zero T
+		return zero, false
+	}
+	first := it.slice[0]
+	it.slice = it.slice[1:]
+	return first, true
+}
+
+func (it *sliceIter[T]) drain(fn func(T)) {
+	for len(it.slice) > 0 {
+		first := it.slice[0]
+		it.slice = it.slice[1:]
+		fn(first)
+	}
+}
+
 // ToSlice consumes an Iterator creating a slice from the yielded values.
 func ToSlice[T any](it Iterator[T]) []T {
-	result := []T{}
+	return AppendTo(it, make([]T, 0, Len(it).UnwrapOr(0)))
+}
+
+// AppendTo consumes an Iterator appending the yielded values to dst and
+// returns the extended slice. Like the built-in append, the capacity of dst is
+// reused when sufficient.
+func AppendTo[T any](it Iterator[T], dst []T) []T {
 	ForEach(it, func(v T) {
-		result = append(result, v)
+		dst = append(dst, v)
 	})
-	return result
+	return dst
 }
 
 // ToString consumes a rune Iterator creating a string.
 func ToString(it Iterator[rune]) string {
-	return string(ToSlice(it))
+	if s, ok := it.(*stringIter); ok && utf8.ValidString(s.input) {
+		// Invalid UTF-8 has to go through decoding to be replaced with
+		// utf8.RuneError.
+		rest := s.input
+		s.input = ""
+		return rest
+	}
+	var b strings.Builder
+	b.Grow(int(Len(it).UnwrapOr(0)))
+	ForEach(it, func(r rune) {
+		b.WriteRune(r)
+	})
+	return b.String()
+}
+
+// ToBytes consumes a byte Iterator creating a byte slice.
+func ToBytes(it Iterator[byte]) []byte {
+	return ToSlice(it)
+}
+
+// ToStringFromBytes consumes a byte Iterator creating a string from the yielded
+// bytes.
+func ToStringFromBytes(it Iterator[byte]) string {
+	var b strings.Builder
+	b.Grow(int(Len(it).UnwrapOr(0)))
+	ForEach(it, func(c byte) {
+		b.WriteByte(c)
+	})
+	return b.String()
+}
+
+// JoinStrings consumes a string Iterator concatenating the yielded strings with
+// sep placed between them.
+func JoinStrings(it Iterator[string], sep string) string {
+	var b strings.Builder
+	first := true
+	ForEach(it, func(s string) {
+		if !first {
+			b.WriteString(sep)
+		}
+		first = false
+		b.WriteString(s)
+	})
+	return b.String()
 }
 
 type mapIter[T, R any] struct {
 	inner Iterator[T]
+	next  func() (T, bool)
 	fn    func(T) R
+	// effectful is set if fn is called for its side effects, in which case
+	// the Iterator cannot be cloned.
+	effectful bool
 }
 
 // Map is an Iterator adapter that transforms each value yielded by the
 // underlying iterator using fn.
 func Map[T, R any](it Iterator[T], fn func(T) R) Iterator[R] {
+	// Unlike Filter, mapping a map is not composed into a single mapIter: the
+	// element type of the inner mapIter's source is unknown here, so it cannot
+	// be recovered with a type assertion. Chained maps are instead connected
+	// through pullFrom, which avoids wrapping elements in Options in between.
 	return &mapIter[T, R]{
 		inner: it,
+		next:  pullFrom(it),
 		fn:    fn,
 	}
 }
 
+// mapEffectful is like Map but for functions called for their side effects.
+func mapEffectful[T, R any](it Iterator[T], fn func(T) R) Iterator[R] {
+	m := Map(it, fn).(*mapIter[T, R])
+	m.effectful = true
+	return m
+}
+
 func (it *mapIter[T, R]) Next() Option[R] {
-	return MapOption(it.inner.Next(), it.fn)
+	v, ok := it.next()
+	if !ok {
+		return None[R]()
+	}
+	return Some(it.fn(v))
+}
+
+func (it *mapIter[T, R]) size() Option[uint] {
+	return Len(it.inner)
+}
+
+func (it *mapIter[T, R]) pull() (R, bool) {
+	v, ok := it.next()
+	if !ok {
+		var zero R
+		return zero, false
+	}
+	return it.fn(v), true
 }
 
 type filterIter[T any] struct {
 	inner Iterator[T]
+	next  func() (T, bool)
 	pred  func(T) bool
+	// stateful is set if pred depends on the elements seen so far, in which
+	// case the Iterator cannot be cloned.
+	stateful bool
 }
 
 // Filter returns an Iterator adapter that yields elements from the underlying
 // Iterator for which pred returns true.
 func Filter[T any](it Iterator[T], pred func(T) bool) Iterator[T] {
+	if f, ok := it.(*filterIter[T]); ok {
+		// Filtering a filter is equivalent to filtering the inner Iterator
+		// with both predicates.
+		first := f.pred
+		return &filterIter[T]{
+			inner: f.inner,
+			next:  f.next,
+			pred: func(v T) bool {
+				return first(v) && pred(v)
+			},
+			stateful: f.stateful,
+		}
+	}
 	return &filterIter[T]{
 		inner: it,
+		next:  pullFrom(it),
 		pred:  pred,
 	}
 }
 
+// filterStateful is like Filter but for predicates that depend on the elements
+// seen so far.
+func filterStateful[T any](it Iterator[T], pred func(T) bool) Iterator[T] {
+	f := Filter(it, pred).(*filterIter[T])
+	f.stateful = true
+	return f
+}
+
 func (it *filterIter[T]) Next() Option[T] {
-	v := it.inner.Next()
-	for v.IsSome() {
-		if it.pred(v.Unwrap()) {
-			break
+	v, ok := it.pull()
+	if !ok {
+		return None[T]()
+	}
+	return Some(v)
+}
+
+func (it *filterIter[T]) pull() (T, bool) {
+	for {
+		v, ok := it.next()
+		if !ok || it.pred(v) {
+			return v, ok
 		}
-		v = it.inner.Next()
 	}
-	return v
 }
 
 type takeIter[T any] struct {
@@ -159,6 +437,20 @@ func (it *takeIter[T]) Next() Option[T] {
 	return v
 }
 
+func (it *takeIter[T]) size() Option[uint] {
+	if it.take == 0 {
+		return Some[uint](0)
+	}
+	n := Len(it.inner)
+	if n.IsSome() && n.Unwrap() < it.take {
+		return n
+	}
+	if n.IsSome() {
+		return Some(it.take)
+	}
+	return None[uint]()
+}
+
 type takeWhileIter[T any] struct {
 	inner Iterator[T]
 	pred  func(T) bool
@@ -166,7 +458,8 @@ type takeWhileIter[T any] struct {
 }
 
 // TakeWhile returns an Iterator adapter that yields values from the underlying
-// Iterator as long as pred predicate function returns true.
+// Iterator as long as pred predicate function returns true. The first element for
+// which pred returns false is consumed from the underlying Iterator.
 func TakeWhile[T any](it Iterator[T], pred func(T) bool) Iterator[T] {
 	return &takeWhileIter[T]{
 		inner: it,
@@ -269,11 +562,9 @@ func (it *repeatIter[T]) Next() Option[T] {
 // Count consumes an Iterator and returns the number of elements it yielded.
 func Count[T any](it Iterator[T]) uint {
 	var length uint
-	v := it.Next()
-	for v.IsSome() {
+	ForEach(it, func(T) {
 		length++
-		v = it.Next()
-	}
+	})
 	return length
 }
 
@@ -303,6 +594,10 @@ func (it *emptyIter[T]) Next() Option[T] {
 	return None[T]()
 }
 
+func (it *emptyIter[T]) Len() uint {
+	return 0
+}
+
 type onceIter[T any] struct {
 	value Option[T]
 }
@@ -320,8 +615,25 @@ func (it *onceIter[T]) Next() Option[T] {
 	return v
 }
 
+func (it *onceIter[T]) Len() uint {
+	if it.value.IsSome() {
+		return 1
+	}
+	return 0
+}
+
 // ForEach consumes the Iterator applying fn to each yielded value.
 func ForEach[T any](it Iterator[T], fn func(T)) {
+	if d, ok := it.(drainer[T]); ok {
+		d.drain(fn)
+		return
+	}
+	if p, ok := it.(puller[T]); ok {
+		for v, ok := p.pull(); ok; v, ok = p.pull() {
+			fn(v)
+		}
+		return
+	}
 	v := it.Next()
 	for v.IsSome() {
 		fn(v.Unwrap())
@@ -338,6 +650,22 @@ func Fold[T any, B any](it Iterator[T], init B, fn func(B, T) B) B {
 	return ret
 }
 
+// TryFold reduces Iterator using function fn until fn returns an error. On
+// error TryFold returns the accumulator preceding the failed call together with
+// the error, leaving the elements after the failing one unconsumed.
+func TryFold[T any, B any](it Iterator[T], init B, fn func(B, T) (B, error)) (B, error) {
+	ret := init
+	next := pullFrom(it)
+	for v, ok := next(); ok; v, ok = next() {
+		acc, err := fn(ret, v)
+		if err != nil {
+			return ret, err
+		}
+		ret = acc
+	}
+	return ret, nil
+}
+
 type fuseIter[T any] struct {
 	inner Iterator[T]
 	done  bool
@@ -363,25 +691,53 @@ func (it *fuseIter[T]) Next() Option[T] {
 	return v
 }
 
+func (it *fuseIter[T]) size() Option[uint] {
+	if it.done {
+		return Some[uint](0)
+	}
+	return Len(it.inner)
+}
+
 type chainIter[T any] struct {
 	first  Iterator[T]
 	second Iterator[T]
+	done   bool
 }
 
-// Chain returns an Iterator that concatenates two iterators.
+// Chain returns an Iterator that concatenates two iterators. The returned
+// Iterator keeps yielding None once both iterators have yielded None.
 func Chain[T any](first Iterator[T], second Iterator[T]) Iterator[T] {
 	return &chainIter[T]{
 		first:  Fuse(first),
 		second: second,
+		done:   false,
 	}
 }
 
 func (it *chainIter[T]) Next() Option[T] {
+	if it.done {
+		return None[T]()
+	}
 	v := it.first.Next()
 	if v.IsSome() {
 		return v
 	}
-	return it.second.Next()
+	v = it.second.Next()
+	if v.IsNone() {
+		it.done = true
+	}
+	return v
+}
+
+func (it *chainIter[T]) size() Option[uint] {
+	if it.done {
+		return Some[uint](0)
+	}
+	first, second := Len(it.first), Len(it.second)
+	if first.IsNone() || second.IsNone() {
+		return None[uint]()
+	}
+	return Some(first.Unwrap() + second.Unwrap())
 }
 
 // Find the first element from Iterator that satisfies pred predicate function.
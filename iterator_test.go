@@ -1,8 +1,14 @@
-package iter
+package iter_test
 
 import (
+	"errors"
+	"math"
 	"reflect"
+	"strings"
 	"testing"
+
+	. "github.com/Soft/iter"
+	"github.com/Soft/iter/itertest"
 )
 
 func equals[T any](t *testing.T, a, b T) {
@@ -13,10 +19,8 @@ func equals[T any](t *testing.T, a, b T) {
 
 func TestSlice(t *testing.T) {
 	it := Slice([]int{1, 2, 3})
-	equals(t, it.Next().Unwrap(), 1)
-	equals(t, it.Next().Unwrap(), 2)
-	equals(t, it.Next().Unwrap(), 3)
-	equals(t, it.Next().IsNone(), true)
+	itertest.AssertYields(t, it, []int{1, 2, 3})
+	itertest.AssertFused(t, it)
 }
 
 func TestRepeat(t *testing.T) {
@@ -27,10 +31,7 @@ func TestRepeat(t *testing.T) {
 }
 
 func TestTake(t *testing.T) {
-	it := Take(Repeat(5), 2)
-	equals(t, it.Next().Unwrap(), 5)
-	equals(t, it.Next().Unwrap(), 5)
-	equals(t, it.Next().IsNone(), true)
+	itertest.AssertYields(t, Take(Repeat(5), 2), []int{5, 5})
 }
 
 func TestMap(t *testing.T) {
@@ -51,21 +52,17 @@ func TestFunc(t *testing.T) {
 			return None[int]()
 		}
 	})
-	equals(t, it.Next().Unwrap(), 0)
-	equals(t, it.Next().Unwrap(), 1)
-	equals(t, it.Next().Unwrap(), 2)
-	equals(t, it.Next().IsNone(), true)
+	itertest.AssertYields(t, it, []int{0, 1, 2})
 }
 
 func TestEmpty(t *testing.T) {
-	it := Empty[int]()
-	equals(t, it.Next().IsNone(), true)
+	itertest.AssertFused(t, Empty[int]())
 }
 
 func TestOnce(t *testing.T) {
 	it := Once[int](10)
-	equals(t, it.Next().Unwrap(), 10)
-	equals(t, it.Next().IsNone(), true)
+	itertest.AssertYields(t, it, []int{10})
+	itertest.AssertFused(t, it)
 }
 
 func TestToSlice(t *testing.T) {
@@ -75,13 +72,22 @@ func TestToSlice(t *testing.T) {
 	equals(t, slice2, []int{})
 }
 
+func TestAppendTo(t *testing.T) {
+	equals(t, AppendTo(Slice([]int{1, 2}), nil), []int{1, 2})
+	buf := make([]int, 0, 4)
+	out := AppendTo(Slice([]int{1, 2, 3}), buf)
+	equals(t, out, []int{1, 2, 3})
+	equals(t, cap(out), 4)
+	equals(t, &out[0] == &buf[:1][0], true)
+	prefix := []int{1, 2}
+	equals(t, AppendTo(Range(3, 6, 1), prefix), []int{1, 2, 3, 4, 5})
+	equals(t, prefix, []int{1, 2})
+}
+
 func TestDrop(t *testing.T) {
-	slice1 := ToSlice(Drop(Slice([]int{1, 2, 3, 4, 5}), 3))
-	equals(t, slice1, []int{4, 5})
-	slice2 := ToSlice(Drop(Slice([]int{1, 2, 3, 4, 5}), 0))
-	equals(t, slice2, []int{1, 2, 3, 4, 5})
-	slice3 := ToSlice(Drop(Slice([]int{1, 2, 3, 4, 5}), 5))
-	equals(t, slice3, []int{})
+	itertest.AssertYields(t, Drop(Slice([]int{1, 2, 3, 4, 5}), 3), []int{4, 5})
+	itertest.AssertYields(t, Drop(Slice([]int{1, 2, 3, 4, 5}), 0), []int{1, 2, 3, 4, 5})
+	itertest.AssertYields(t, Drop(Slice([]int{1, 2, 3, 4, 5}), 5), nil)
 }
 
 func TestDropWhile(t *testing.T) {
@@ -125,6 +131,29 @@ func TestFold(t *testing.T) {
 	equals(t, ret, 15)
 }
 
+func TestTryFold(t *testing.T) {
+	add := func(acc, i int) (int, error) {
+		return acc + i, nil
+	}
+	ret, err := TryFold(Slice([]int{1, 2, 3, 4, 5}), 0, add)
+	equals(t, ret, Fold(Slice([]int{1, 2, 3, 4, 5}), 0, func(acc, i int) int {
+		return acc + i
+	}))
+	equals(t, err, nil)
+	errTooLarge := errors.New("too large")
+	counting := itertest.Counting(Range(0, 10, 1))
+	ret, err = TryFold[int](counting, 0, func(acc, i int) (int, error) {
+		if i > 3 {
+			return -1, errTooLarge
+		}
+		return acc + i, nil
+	})
+	equals(t, ret, 6)
+	equals(t, err, errTooLarge)
+	equals(t, counting.Calls(), 5)
+	itertest.AssertYields[int](t, counting, []int{5, 6, 7, 8, 9})
+}
+
 func TestFuse(t *testing.T) {
 	state := true
 	it := Fuse(
@@ -138,8 +167,7 @@ func TestFuse(t *testing.T) {
 		}),
 	)
 	equals(t, it.Next().Unwrap(), 1)
-	equals(t, it.Next().IsNone(), true)
-	equals(t, it.Next().IsNone(), true)
+	itertest.AssertFused(t, it)
 }
 
 func TestChain(t *testing.T) {
@@ -150,12 +178,36 @@ func TestChain(t *testing.T) {
 	equals(t, ToSlice(it), []int{1, 2, 3, 4})
 }
 
+// resurrecting returns an Iterator that yields None after each value.
+func resurrecting() Iterator[int] {
+	state := false
+	return Func(func() Option[int] {
+		state = !state
+		if state {
+			return Some(1)
+		}
+		return None[int]()
+	})
+}
+
+func TestChainFused(t *testing.T) {
+	first := itertest.Counting(resurrecting())
+	second := itertest.Counting(resurrecting())
+	it := Chain[int](first, second)
+	equals(t, it.Next().Unwrap(), 1)
+	equals(t, it.Next().Unwrap(), 1)
+	itertest.AssertFused(t, it)
+	equals(t, first.Calls(), uint(2))
+	equals(t, second.Calls(), uint(2))
+}
+
 func TestFind(t *testing.T) {
-	it := Slice([]int{1, 2, 3, 4, 5})
-	v := Find(it, func(i int) bool {
+	it := itertest.Counting(Slice([]int{1, 2, 3, 4, 5}))
+	v := Find[int](it, func(i int) bool {
 		return i > 3
 	})
 	equals(t, v, Some(4))
+	equals(t, it.Calls(), uint(4))
 }
 
 func TestFlatten(t *testing.T) {
@@ -169,11 +221,46 @@ func TestFlatten(t *testing.T) {
 }
 
 func TestRange(t *testing.T) {
-	equals(t, ToSlice(Range(0, 5, 1)), []int{0, 1, 2, 3, 4})
-	equals(t, ToSlice(Range(0, 0, 1)), []int{})
-	equals(t, ToSlice(Range(0, -5, -1)), []int{0, -1, -2, -3, -4})
-	equals(t, ToSlice(Range(5, 10, -1)), []int{})
-	equals(t, ToSlice(Range(5, 10, 1)), []int{5, 6, 7, 8, 9})
+	itertest.AssertYields(t, Range(0, 5, 1), []int{0, 1, 2, 3, 4})
+	itertest.AssertYields(t, Range(0, 0, 1), nil)
+	itertest.AssertYields(t, Range(0, -5, -1), []int{0, -1, -2, -3, -4})
+	itertest.AssertYields(t, Range(5, 10, -1), nil)
+	itertest.AssertYields(t, Range(5, 10, 1), []int{5, 6, 7, 8, 9})
+}
+
+func TestRangeLimits(t *testing.T) {
+	itertest.AssertYields(t, Range(0, 10, math.MaxInt), []int{0})
+	itertest.AssertYields(t, Range(math.MaxInt-5, math.MaxInt, 4), []int{math.MaxInt - 5, math.MaxInt - 1})
+	itertest.AssertYields(t, Range(math.MinInt+5, math.MinInt, -4), []int{math.MinInt + 5, math.MinInt + 1})
+	itertest.AssertYields(t, Range(math.MinInt, math.MaxInt, math.MaxInt), []int{math.MinInt, -1, math.MaxInt - 1})
+	equals(t, ToSlice(Take(Range(math.MinInt, math.MaxInt, 1), 2)), []int{math.MinInt, math.MinInt + 1})
+}
+
+func TestRangeInclusive(t *testing.T) {
+	itertest.AssertYields(t, RangeInclusive(1, 10, 3), []int{1, 4, 7, 10})
+	itertest.AssertYields(t, RangeInclusive(1, 9, 3), []int{1, 4, 7})
+	itertest.AssertYields(t, RangeInclusive(0, 0, 1), []int{0})
+	itertest.AssertYields(t, RangeInclusive(10, 1, -3), []int{10, 7, 4, 1})
+	itertest.AssertYields(t, RangeInclusive(10, 2, -3), []int{10, 7, 4})
+	itertest.AssertYields(t, RangeInclusive(0, -2, -1), []int{0, -1, -2})
+	itertest.AssertYields(t, RangeInclusive(5, 1, 1), nil)
+	itertest.AssertYields(t, RangeInclusive(0, 5, 0), nil)
+	equals(t, ToSlice(Take(RangeInclusive(5, 0, 0), 2)), ToSlice(Take(Range(5, 0, 0), 2)))
+	equals(t, Len(RangeInclusive(1, 10, 3)), Some[uint](4))
+}
+
+func TestRangeInclusiveLimits(t *testing.T) {
+	itertest.AssertYields(t, RangeInclusive(math.MaxInt-2, math.MaxInt, 1), []int{math.MaxInt - 2, math.MaxInt - 1, math.MaxInt})
+	itertest.AssertYields(t, RangeInclusive(math.MinInt+2, math.MinInt, -1), []int{math.MinInt + 2, math.MinInt + 1, math.MinInt})
+	itertest.AssertYields(t, RangeInclusive(math.MaxInt, math.MaxInt, 1), []int{math.MaxInt})
+	itertest.AssertYields(t, RangeInclusive(math.MinInt, math.MaxInt, math.MaxInt), []int{math.MinInt, -1, math.MaxInt - 1})
+	itertest.AssertYields(t, RangeInclusive(0, math.MaxInt, math.MaxInt), []int{0, math.MaxInt})
+	equals(t, Len(RangeInclusive(math.MaxInt-2, math.MaxInt, 1)), Some[uint](3))
+	equals(t, Len(RangeInclusive(math.MinInt+1, math.MaxInt, 1)), Some[uint](math.MaxUint))
+	equals(t, Len(RangeInclusive(math.MinInt, math.MaxInt, 1)), None[uint]())
+	equals(t, Last(RangeInclusive(math.MinInt, math.MaxInt, 1)), Some(math.MaxInt))
+	equals(t, Last(RangeInclusive(math.MaxInt, math.MinInt, -1)), Some(math.MinInt))
+	itertest.AssertYields(t, Reverse(RangeInclusive(math.MaxInt-2, math.MaxInt, 1)), []int{math.MaxInt, math.MaxInt - 1, math.MaxInt - 2})
 }
 
 func TestAll(t *testing.T) {
@@ -295,3 +382,281 @@ func TestToString(t *testing.T) {
 		"Hello",
 	)
 }
+
+func TestForEachAfterNext(t *testing.T) {
+	it := Slice([]int{1, 2, 3, 4, 5})
+	equals(t, it.Next().Unwrap(), 1)
+	equals(t, it.Next().Unwrap(), 2)
+	var ret []int
+	ForEach(it, func(i int) {
+		ret = append(ret, i)
+	})
+	equals(t, ret, []int{3, 4, 5})
+	equals(t, it.Next().IsNone(), true)
+
+	r := Range(0, 10, 2)
+	equals(t, r.Next().Unwrap(), 0)
+	equals(t, Fold(r, 0, func(acc, i int) int {
+		return acc + i
+	}), 20)
+	equals(t, r.Next().IsNone(), true)
+}
+
+func TestCountAfterNext(t *testing.T) {
+	it := Slice([]int{1, 2, 3, 4, 5})
+	it.Next()
+	equals(t, Count(it), uint(4))
+	equals(t, it.Next().IsNone(), true)
+	equals(t, Count(Range(5, 0, -1)), uint(5))
+}
+
+const benchSize = 1000000
+
+func sum(it Iterator[int]) int {
+	return Fold(it, 0, func(acc, i int) int {
+		return acc + i
+	})
+}
+
+func BenchmarkSumSlice(b *testing.B) {
+	slice := ToSlice(Range(0, benchSize, 1))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		sum(Slice(slice))
+	}
+}
+
+func BenchmarkSumSliceNext(b *testing.B) {
+	slice := ToSlice(Range(0, benchSize, 1))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		sum(Func(Slice(slice).Next))
+	}
+}
+
+func BenchmarkSumRange(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		sum(Range(0, benchSize, 1))
+	}
+}
+
+func BenchmarkSumRangeNext(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		sum(Func(Range(0, benchSize, 1).Next))
+	}
+}
+
+func TestMapFilterComposition(t *testing.T) {
+	var calls []string
+	double := func(i int) int {
+		calls = append(calls, "double")
+		return i * 2
+	}
+	even := func(i int) bool {
+		calls = append(calls, "even")
+		return i%4 == 0
+	}
+	small := func(i int) bool {
+		calls = append(calls, "small")
+		return i < 8
+	}
+	inc := func(i int) int {
+		calls = append(calls, "inc")
+		return i + 1
+	}
+	it := Map(Filter(Filter(Map(Slice([]int{1, 2, 3, 4}), double), even), small), inc)
+	equals(t, it.Next().Unwrap(), 5)
+	equals(t, calls, []string{"double", "even", "double", "even", "small", "inc"})
+	calls = nil
+	equals(t, ToSlice(it), []int{})
+	equals(t, calls, []string{"double", "even", "double", "even", "small"})
+	equals(t, it.Next().IsNone(), true)
+}
+
+func TestMapMapComposition(t *testing.T) {
+	var calls []string
+	f := func(i int) int {
+		calls = append(calls, "f")
+		return i * 2
+	}
+	g := func(i int) string {
+		calls = append(calls, "g")
+		return strings.Repeat("x", i)
+	}
+	it := Map(Map(Slice([]int{1, 2, 3}), f), g)
+	equals(t, it.Next().Unwrap(), "xx")
+	equals(t, calls, []string{"f", "g"})
+	calls = nil
+	equals(t, ToSlice(it), []string{"xxxx", "xxxxxx"})
+	equals(t, calls, []string{"f", "g", "f", "g"})
+	equals(t, it.Next().IsNone(), true)
+}
+
+func TestFilterSharedInner(t *testing.T) {
+	first := Filter(Range(0, 10, 1), func(i int) bool {
+		return i%2 == 0
+	})
+	second := Filter(first, func(i int) bool {
+		return i%3 == 0
+	})
+	equals(t, first.Next().Unwrap(), 0)
+	equals(t, second.Next().Unwrap(), 6)
+	equals(t, first.Next().Unwrap(), 8)
+	equals(t, second.Next().IsNone(), true)
+}
+
+func chain3(it Iterator[int]) Iterator[int] {
+	return Map(
+		Filter(
+			Map(it, func(i int) int {
+				return i * 3
+			}),
+			func(i int) bool {
+				return i%2 == 0
+			},
+		),
+		func(i int) int {
+			return i + 1
+		},
+	)
+}
+
+func BenchmarkChain3(b *testing.B) {
+	slice := ToSlice(Range(0, benchSize, 1))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		it := chain3(Slice(slice))
+		for v := it.Next(); v.IsSome(); v = it.Next() {
+		}
+	}
+}
+
+func BenchmarkChain3Unfused(b *testing.B) {
+	slice := ToSlice(Range(0, benchSize, 1))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		it := Func(Map(
+			Func(Filter(
+				Func(Map(Func(Slice(slice).Next), func(i int) int {
+					return i * 3
+				}).Next),
+				func(i int) bool {
+					return i%2 == 0
+				},
+			).Next),
+			func(i int) int {
+				return i + 1
+			},
+		).Next)
+		for v := it.Next(); v.IsSome(); v = it.Next() {
+		}
+	}
+}
+
+func TestLen(t *testing.T) {
+	it := Slice([]int{1, 2, 3})
+	equals(t, Len(it), Some[uint](3))
+	it.Next()
+	equals(t, Len(it), Some[uint](2))
+	ToSlice(it)
+	equals(t, Len(it), Some[uint](0))
+
+	equals(t, Len(Range(0, 10, 3)), Some[uint](4))
+	equals(t, Len(Range(10, 0, -3)), Some[uint](4))
+	equals(t, Len(Range(0, 10, -1)), Some[uint](0))
+	equals(t, Len(Range(5, 0, 0)), None[uint]())
+	equals(t, Len(Range(0, 10, math.MaxInt)), Some[uint](1))
+	equals(t, Len(Range(0, -10, math.MinInt)), Some[uint](1))
+	equals(t, Len(Range(math.MinInt, math.MaxInt, 1)), Some[uint](math.MaxUint))
+	equals(t, Len(Range(math.MaxInt, math.MinInt, -1)), Some[uint](math.MaxUint))
+	equals(t, Len(Range(math.MinInt, math.MaxInt, math.MaxInt)), Some[uint](3))
+	r := Range(0, 5, 2)
+	for n := Len(r).Unwrap(); n > 0; n-- {
+		r.Next()
+		equals(t, Len(r), Some(n-1))
+	}
+	equals(t, r.Next().IsNone(), true)
+
+	once := Once(1)
+	equals(t, Len(once), Some[uint](1))
+	once.Next()
+	equals(t, Len(once), Some[uint](0))
+	equals(t, Len(Empty[int]()), Some[uint](0))
+	equals(t, Len(Repeat(1)), None[uint]())
+}
+
+func TestLenAdapters(t *testing.T) {
+	equals(t, Len(Map(Slice([]int{1, 2, 3}), square)), Some[uint](3))
+	equals(t, Len(Filter(Slice([]int{1, 2, 3}), isOdd)), None[uint]())
+	equals(t, Len(Take(Slice([]int{1, 2, 3}), 2)), Some[uint](2))
+	equals(t, Len(Take(Slice([]int{1, 2, 3}), 5)), Some[uint](3))
+	equals(t, Len(Take(Repeat(1), 5)), None[uint]())
+	equals(t, Len(Take(Repeat(1), 0)), Some[uint](0))
+	chain := Chain(Slice([]int{1, 2}), Range(0, 3, 1))
+	equals(t, Len(chain), Some[uint](5))
+	chain.Next()
+	chain.Next()
+	chain.Next()
+	equals(t, Len(chain), Some[uint](2))
+	equals(t, Len(Chain(Slice([]int{1, 2}), Repeat(1))), None[uint]())
+	equals(t, cap(ToSlice(Map(Range(0, 10, 1), square))), 10)
+}
+
+func TestToStringUTF8(t *testing.T) {
+	equals(t, ToString(Chain(String("héllo "), Slice([]rune("世界")))), "héllo 世界")
+	it := String("añb")
+	it.Next()
+	equals(t, ToString(it), "ñb")
+	equals(t, it.Next().IsNone(), true)
+	equals(t, ToString(String("a\xffb")), "a\uFFFDb")
+	equals(t, ToString(Filter(String("a€b"), func(r rune) bool {
+		return r != 'b'
+	})), "a€")
+}
+
+func TestToBytes(t *testing.T) {
+	equals(t, ToBytes(Slice([]byte("héllo"))), []byte("héllo"))
+	equals(t, ToBytes(Empty[byte]()), []byte{})
+	equals(t, ToStringFromBytes(Slice([]byte("héllo"))), "héllo")
+	equals(t, ToStringFromBytes(Take(Slice([]byte("世界")), 3)), "世")
+}
+
+func TestJoinStrings(t *testing.T) {
+	equals(t, JoinStrings(Empty[string](), ", "), "")
+	equals(t, JoinStrings(Once("a"), ", "), "a")
+	equals(t, JoinStrings(Slice([]string{"a", "b", "c"}), ", "), "a, b, c")
+	equals(t, JoinStrings(Slice([]string{"", "", ""}), "-"), "--")
+	equals(t, JoinStrings(Slice([]string{"a", "b"}), ""), "ab")
+}
+
+func benchmarkText() string {
+	return strings.Repeat("iterators ⚙ ", 1<<20/14)
+}
+
+func BenchmarkToString(b *testing.B) {
+	text := benchmarkText()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		ToString(Map(String(text), func(r rune) rune {
+			return r
+		}))
+	}
+}
+
+func BenchmarkToStringRunes(b *testing.B) {
+	text := benchmarkText()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = string(ToSlice(Map(String(text), func(r rune) rune {
+			return r
+		})))
+	}
+}
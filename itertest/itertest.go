@@ -0,0 +1,90 @@
+// Package itertest provides helpers for testing code that produces or
+// consumes Iterators.
+package itertest
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Soft/iter"
+)
+
+// AssertYields consumes the Iterator failing the test unless it yields exactly
+// the elements of want. Elements are compared using reflect.DeepEqual.
+func AssertYields[T any](t testing.TB, it iter.Iterator[T], want []T) {
+	t.Helper()
+	got := iter.ToSlice(it)
+	if len(got) != len(want) {
+		t.Fatalf("yielded %d elements %+v, want %d elements %+v", len(got), got, len(want), want)
+	}
+	for i := range got {
+		if !reflect.DeepEqual(got[i], want[i]) {
+			t.Fatalf("element %d is %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+// AssertExhausted fails the test if the Iterator yields a value.
+func AssertExhausted[T any](t testing.TB, it iter.Iterator[T]) {
+	t.Helper()
+	if v := it.Next(); v.IsSome() {
+		t.Fatalf("yielded %+v, want None", v.Unwrap())
+	}
+}
+
+// AssertFused fails the test unless the Iterator yields None on two
+// consecutive calls to Next.
+func AssertFused[T any](t testing.TB, it iter.Iterator[T]) {
+	t.Helper()
+	AssertExhausted(t, it)
+	AssertExhausted(t, it)
+}
+
+// CountingIterator[T] is an Iterator adapter that counts how many times Next
+// has been called.
+type CountingIterator[T any] struct {
+	inner iter.Iterator[T]
+	calls uint
+}
+
+// Counting returns a CountingIterator wrapping an Iterator.
+func Counting[T any](it iter.Iterator[T]) *CountingIterator[T] {
+	return &CountingIterator[T]{
+		inner: it,
+	}
+}
+
+func (it *CountingIterator[T]) Next() iter.Option[T] {
+	it.calls++
+	return it.inner.Next()
+}
+
+// Calls returns the number of times Next has been called.
+func (it *CountingIterator[T]) Calls() uint {
+	return it.calls
+}
+
+type failAfterIter[T any] struct {
+	t     testing.TB
+	inner iter.Iterator[T]
+	left  uint
+}
+
+// FailAfter returns an Iterator adapter that fails the test if Next is called
+// more than n times. It is useful for asserting that a consumer stops early.
+func FailAfter[T any](t testing.TB, it iter.Iterator[T], n uint) iter.Iterator[T] {
+	return &failAfterIter[T]{
+		t:     t,
+		inner: it,
+		left:  n,
+	}
+}
+
+func (it *failAfterIter[T]) Next() iter.Option[T] {
+	if it.left == 0 {
+		it.t.Helper()
+		it.t.Fatalf("Next called too many times")
+	}
+	it.left--
+	return it.inner.Next()
+}
@@ -0,0 +1,93 @@
+package itertest
+
+import (
+	"fmt"
+	"runtime"
+	"testing"
+
+	"github.com/Soft/iter"
+)
+
+// recorder captures test failures instead of reporting them.
+type recorder struct {
+	testing.TB
+	failures []string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Fatalf(format string, args ...any) {
+	r.failures = append(r.failures, fmt.Sprintf(format, args...))
+	runtime.Goexit()
+}
+
+// run calls fn with a recorder returning the recorded failures.
+func run(fn func(t testing.TB)) []string {
+	r := &recorder{}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		fn(r)
+	}()
+	<-done
+	return r.failures
+}
+
+func TestAssertYields(t *testing.T) {
+	AssertYields(t, iter.Slice([]int{1, 2, 3}), []int{1, 2, 3})
+	AssertYields(t, iter.Empty[int](), nil)
+	failures := run(func(t testing.TB) {
+		AssertYields(t, iter.Slice([]int{1, 2, 3}), []int{1, 5, 3})
+	})
+	if len(failures) != 1 || failures[0] != "element 1 is 2, want 5" {
+		t.Fatal(failures)
+	}
+	failures = run(func(t testing.TB) {
+		AssertYields(t, iter.Slice([]int{1, 2}), []int{1, 2, 3})
+	})
+	if len(failures) != 1 {
+		t.Fatal(failures)
+	}
+}
+
+func TestAssertFused(t *testing.T) {
+	AssertFused(t, iter.Empty[int]())
+	state := false
+	resurrecting := iter.Func(func() iter.Option[int] {
+		state = !state
+		if state {
+			return iter.None[int]()
+		}
+		return iter.Some(1)
+	})
+	failures := run(func(t testing.TB) {
+		AssertFused(t, resurrecting)
+	})
+	if len(failures) != 1 || failures[0] != "yielded 1, want None" {
+		t.Fatal(failures)
+	}
+}
+
+func TestCounting(t *testing.T) {
+	it := Counting(iter.Slice([]int{1, 2, 3}))
+	iter.Find[int](it, func(i int) bool {
+		return i == 2
+	})
+	if it.Calls() != 2 {
+		t.Fatal(it.Calls())
+	}
+	AssertYields[int](t, it, []int{3})
+	if it.Calls() != 4 {
+		t.Fatal(it.Calls())
+	}
+}
+
+func TestFailAfter(t *testing.T) {
+	AssertYields(t, FailAfter(t, iter.Slice([]int{1, 2}), 3), []int{1, 2})
+	failures := run(func(t testing.TB) {
+		iter.Count(FailAfter(t, iter.Slice([]int{1, 2, 3}), 2))
+	})
+	if len(failures) != 1 {
+		t.Fatal(failures)
+	}
+}
@@ -0,0 +1,353 @@
+package iter
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+)
+
+// Closer is implemented by Iterators backed by goroutines. Close must be called
+// if such an Iterator is abandoned before it is exhausted so that the goroutines
+// can exit.
+type Closer interface {
+	// Close stops the goroutines backing the Iterator. Next yields None after
+	// Close has been called.
+	Close()
+}
+
+// Close stops the goroutines backing the Iterator if it implements Closer.
+func Close[T any](it Iterator[T]) {
+	if c, ok := it.(Closer); ok {
+		c.Close()
+	}
+}
+
+// outcome holds a value computed on another goroutine or the value recovered
+// from a panic that occurred while computing it.
+type outcome[T any] struct {
+	value     T
+	panicked  bool
+	recovered any
+}
+
+// protect calls fn capturing a possible panic in the returned outcome.
+func protect[T any](fn func() T) (o outcome[T]) {
+	completed := false
+	defer func() {
+		if !completed {
+			o.panicked = true
+			o.recovered = recover()
+		}
+	}()
+	o.value = fn()
+	completed = true
+	return o
+}
+
+func checkWorkers(workers int) {
+	if workers < 1 {
+		panic("Number of workers must be greater than zero.")
+	}
+}
+
+type parMapIter[R any] struct {
+	results chan outcome[R]
+	done    chan struct{}
+	once    sync.Once
+}
+
+// closed reports if done has been closed.
+func closed(done chan struct{}) bool {
+	select {
+	case <-done:
+		return true
+	default:
+		return false
+	}
+}
+
+// ParMap is an Iterator adapter that transforms each value yielded by the
+// underlying Iterator using fn on a pool of workers goroutines. Values are
+// yielded in the order their transformations complete. A panic in fn is
+// propagated to the caller of Next. The returned Iterator implements Closer.
+func ParMap[T, R any](it Iterator[T], workers int, fn func(T) R) Iterator[R] {
+	checkWorkers(workers)
+	p := &parMapIter[R]{
+		results: make(chan outcome[R]),
+		done:    make(chan struct{}),
+	}
+	jobs := make(chan T)
+	var wg sync.WaitGroup
+	wg.Add(workers + 1)
+	go func() {
+		defer wg.Done()
+		defer close(jobs)
+		for {
+			v := protect(it.Next)
+			if v.panicked {
+				p.send(outcome[R]{panicked: true, recovered: v.recovered})
+				return
+			}
+			if v.value.IsNone() {
+				return
+			}
+			select {
+			case jobs <- v.value.Unwrap():
+			case <-p.done:
+				return
+			}
+		}
+	}()
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer wg.Done()
+			for v := range jobs {
+				v := v
+				if !p.send(protect(func() R { return fn(v) })) {
+					return
+				}
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(p.results)
+	}()
+	return p
+}
+
+func (it *parMapIter[R]) send(o outcome[R]) bool {
+	select {
+	case it.results <- o:
+		return true
+	case <-it.done:
+		return false
+	}
+}
+
+func (it *parMapIter[R]) Next() Option[R] {
+	if closed(it.done) {
+		return None[R]()
+	}
+	select {
+	case <-it.done:
+		return None[R]()
+	case o, ok := <-it.results:
+		if !ok {
+			return None[R]()
+		}
+		if o.panicked {
+			it.Close()
+			panic(o.recovered)
+		}
+		return Some(o.value)
+	}
+}
+
+func (it *parMapIter[R]) Close() {
+	it.once.Do(func() {
+		close(it.done)
+	})
+}
+
+type orderedJob[T, R any] struct {
+	value T
+	slot  chan outcome[R]
+}
+
+type parMapOrderedIter[R any] struct {
+	pending chan chan outcome[R]
+	done    chan struct{}
+	once    sync.Once
+}
+
+// ParMapOrdered is an Iterator adapter that transforms each value yielded by
+// the underlying Iterator using fn on a pool of workers goroutines. Unlike
+// ParMap, values are yielded in the order of the underlying Iterator. At most
+// 2*workers transformed values are buffered ahead of the consumer. A panic in fn is
+// propagated to the caller of Next. The returned Iterator implements Closer.
+func ParMapOrdered[T, R any](it Iterator[T], workers int, fn func(T) R) Iterator[R] {
+	checkWorkers(workers)
+	p := &parMapOrderedIter[R]{
+		pending: make(chan chan outcome[R], 2*workers),
+		done:    make(chan struct{}),
+	}
+	jobs := make(chan orderedJob[T, R])
+	go func() {
+		defer close(jobs)
+		defer close(p.pending)
+		for {
+			slot := make(chan outcome[R], 1)
+			v := protect(it.Next)
+			if v.panicked {
+				slot <- outcome[R]{panicked: true, recovered: v.recovered}
+				p.push(slot)
+				return
+			}
+			if v.value.IsNone() {
+				return
+			}
+			if !p.push(slot) {
+				return
+			}
+			select {
+			case jobs <- orderedJob[T, R]{value: v.value.Unwrap(), slot: slot}:
+			case <-p.done:
+				return
+			}
+		}
+	}()
+	for i := 0; i < workers; i++ {
+		go func() {
+			for job := range jobs {
+				job := job
+				job.slot <- protect(func() R { return fn(job.value) })
+			}
+		}()
+	}
+	return p
+}
+
+func (it *parMapOrderedIter[R]) push(slot chan outcome[R]) bool {
+	select {
+	case it.pending <- slot:
+		return true
+	case <-it.done:
+		return false
+	}
+}
+
+func (it *parMapOrderedIter[R]) Next() Option[R] {
+	if closed(it.done) {
+		return None[R]()
+	}
+	var slot chan outcome[R]
+	select {
+	case <-it.done:
+		return None[R]()
+	case s, ok := <-it.pending:
+		if !ok {
+			return None[R]()
+		}
+		slot = s
+	}
+	select {
+	case <-it.done:
+		return None[R]()
+	case o := <-slot:
+		if o.panicked {
+			it.Close()
+			panic(o.recovered)
+		}
+		return Some(o.value)
+	}
+}
+
+func (it *parMapOrderedIter[R]) Close() {
+	it.once.Do(func() {
+		close(it.done)
+	})
+}
+
+type verdict[T any] struct {
+	value T
+	keep  bool
+}
+
+type parFilterIter[T any] struct {
+	inner Iterator[verdict[T]]
+}
+
+// ParFilter returns an Iterator adapter that yields elements from the
+// underlying Iterator for which pred returns true. pred is evaluated on a pool
+// of workers goroutines but elements are yielded in the order of the underlying
+// Iterator. At most 2*workers elements are buffered ahead of the consumer. A
+// panic in pred is propagated to the caller of Next. The returned Iterator
+// implements Closer.
+func ParFilter[T any](it Iterator[T], workers int, pred func(T) bool) Iterator[T] {
+	return &parFilterIter[T]{
+		inner: ParMapOrdered(it, workers, func(v T) verdict[T] {
+			return verdict[T]{value: v, keep: pred(v)}
+		}),
+	}
+}
+
+func (it *parFilterIter[T]) Next() Option[T] {
+	v := it.inner.Next()
+	for v.IsSome() {
+		if u := v.Unwrap(); u.keep {
+			return Some(u.value)
+		}
+		v = it.inner.Next()
+	}
+	return None[T]()
+}
+
+func (it *parFilterIter[T]) Close() {
+	Close(it.inner)
+}
+
+// ParForEach consumes the Iterator applying fn to each yielded value on a pool
+// of workers goroutines. The first error returned by fn cancels the context
+// passed to the other calls, stops the consumption of the Iterator and is
+// returned. A panic in fn likewise stops the consumption and is propagated to
+// the caller once all workers have stopped. If ctx is cancelled before every
+// value has been processed, ParForEach stops and returns ctx.Err(). The
+// Iterator is only advanced from the calling goroutine.
+func ParForEach[T any](ctx context.Context, it Iterator[T], workers int, fn func(context.Context, T) error) error {
+	checkWorkers(workers)
+	parent := ctx
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	var (
+		wg      sync.WaitGroup
+		once    sync.Once
+		first   outcome[error]
+		skipped int32
+	)
+	jobs := make(chan T)
+	wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer wg.Done()
+			for v := range jobs {
+				if ctx.Err() != nil {
+					atomic.StoreInt32(&skipped, 1)
+					continue
+				}
+				v := v
+				o := protect(func() error { return fn(ctx, v) })
+				if o.panicked || o.value != nil {
+					once.Do(func() {
+						first = o
+						cancel()
+					})
+				}
+			}
+		}()
+	}
+	exhausted := false
+	for ctx.Err() == nil {
+		v := it.Next()
+		if v.IsNone() {
+			exhausted = true
+			break
+		}
+		select {
+		case jobs <- v.Unwrap():
+		case <-ctx.Done():
+			atomic.StoreInt32(&skipped, 1)
+		}
+	}
+	close(jobs)
+	wg.Wait()
+	switch {
+	case first.panicked:
+		panic(first.recovered)
+	case first.value != nil:
+		return first.value
+	case exhausted && skipped == 0:
+		return nil
+	}
+	return parent.Err()
+}
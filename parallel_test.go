@@ -0,0 +1,303 @@
+package iter_test
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"sort"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	. "github.com/Soft/iter"
+)
+
+// highWater tracks the maximum number of concurrent calls between enter and
+// leave.
+type highWater struct {
+	current, max int64
+}
+
+func (h *highWater) enter() {
+	n := atomic.AddInt64(&h.current, 1)
+	for {
+		max := atomic.LoadInt64(&h.max)
+		if n <= max || atomic.CompareAndSwapInt64(&h.max, max, n) {
+			return
+		}
+	}
+}
+
+func (h *highWater) leave() {
+	atomic.AddInt64(&h.current, -1)
+}
+
+// waitGoroutines waits for the number of goroutines to drop to n.
+func waitGoroutines(t *testing.T, n int) {
+	deadline := time.Now().Add(time.Second)
+	for runtime.NumGoroutine() > n {
+		if time.Now().After(deadline) {
+			t.Fatalf("%d goroutines running, expected %d", runtime.NumGoroutine(), n)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func square(i int) int {
+	return i * i
+}
+
+func TestParMap(t *testing.T) {
+	got := ToSlice(ParMap(Range(0, 100, 1), 4, square))
+	sort.Ints(got)
+	equals(t, got, ToSlice(Map(Range(0, 100, 1), square)))
+	equals(t, ToSlice(ParMap(Empty[int](), 4, square)), []int{})
+}
+
+func TestParMapConcurrency(t *testing.T) {
+	var h highWater
+	ForEach(ParMap(Range(0, 50, 1), 3, func(i int) int {
+		h.enter()
+		defer h.leave()
+		time.Sleep(time.Millisecond)
+		return i
+	}), func(int) {})
+	equals(t, h.max > 1, true)
+	equals(t, h.max <= 3, true)
+}
+
+func TestParMapClose(t *testing.T) {
+	before := runtime.NumGoroutine()
+	it := ParMap(Range(0, 1000, 1), 4, square)
+	equals(t, Count(Take(it, 3)), uint(3))
+	Close(it)
+	equals(t, it.Next().IsNone(), true)
+	waitGoroutines(t, before)
+}
+
+func TestParMapPanic(t *testing.T) {
+	before := runtime.NumGoroutine()
+	defer func() {
+		equals(t, recover(), any("boom"))
+		waitGoroutines(t, before)
+	}()
+	ToSlice(ParMap(Range(0, 100, 1), 4, func(i int) int {
+		if i == 10 {
+			panic("boom")
+		}
+		return i
+	}))
+	t.Fatal("expected panic")
+}
+
+func TestParMapOrdered(t *testing.T) {
+	equals(
+		t,
+		ToSlice(ParMapOrdered(Range(0, 100, 1), 4, square)),
+		ToSlice(Map(Range(0, 100, 1), square)),
+	)
+	equals(t, ToSlice(ParMapOrdered(Empty[int](), 4, square)), []int{})
+}
+
+func TestParMapOrderedConcurrency(t *testing.T) {
+	entered := make(chan struct{}, 8)
+	release := make(chan struct{})
+	it := ParMapOrdered(Range(0, 8, 1), 8, func(i int) int {
+		entered <- struct{}{}
+		<-release
+		return i
+	})
+	defer Close(it)
+	// Every call blocks until all of them have started, which only completes
+	// if the calls run concurrently.
+	timeout := time.After(5 * time.Second)
+	for i := 0; i < 8; i++ {
+		select {
+		case <-entered:
+		case <-timeout:
+			t.Fatalf("%d calls started, expected 8", i)
+		}
+	}
+	close(release)
+	equals(t, ToSlice(it), []int{0, 1, 2, 3, 4, 5, 6, 7})
+}
+
+func TestParMapOrderedWindow(t *testing.T) {
+	started := make(chan int, 100)
+	release := make(chan struct{})
+	it := ParMapOrdered(Range(0, 100, 1), 2, func(i int) int {
+		started <- i
+		if i == 0 {
+			<-release
+		}
+		return i
+	})
+	defer Close(it)
+	// With the first value blocked, the window admits 2*workers values.
+	var window []int
+	for len(window) < 4 {
+		window = append(window, <-started)
+	}
+	sort.Ints(window)
+	equals(t, window, []int{0, 1, 2, 3})
+	// A correct window never starts another call, so this wait cannot fail
+	// spuriously.
+	select {
+	case i := <-started:
+		t.Fatalf("value %d started outside of the window", i)
+	case <-time.After(20 * time.Millisecond):
+	}
+	close(release)
+	equals(t, ToSlice(it), ToSlice(Range(0, 100, 1)))
+}
+
+func TestParMapOrderedClose(t *testing.T) {
+	before := runtime.NumGoroutine()
+	it := ParMapOrdered(Range(0, 1000, 1), 4, square)
+	equals(t, ToSlice(Take(it, 3)), []int{0, 1, 4})
+	Close(it)
+	equals(t, it.Next().IsNone(), true)
+	waitGoroutines(t, before)
+}
+
+func TestParMapOrderedPanic(t *testing.T) {
+	defer func() {
+		equals(t, recover(), any("boom"))
+	}()
+	ToSlice(ParMapOrdered(Range(0, 100, 1), 4, func(i int) int {
+		if i == 10 {
+			panic("boom")
+		}
+		return i
+	}))
+	t.Fatal("expected panic")
+}
+
+func isOdd(i int) bool {
+	return i%2 == 1
+}
+
+func TestParFilter(t *testing.T) {
+	equals(
+		t,
+		ToSlice(ParFilter(Range(0, 100, 1), 4, isOdd)),
+		ToSlice(Filter(Range(0, 100, 1), isOdd)),
+	)
+	var h highWater
+	got := ToSlice(ParFilter(Range(0, 50, 1), 3, func(i int) bool {
+		h.enter()
+		defer h.leave()
+		time.Sleep(time.Duration(50-i) * 100 * time.Microsecond)
+		return isOdd(i)
+	}))
+	equals(t, got, ToSlice(Filter(Range(0, 50, 1), isOdd)))
+	equals(t, h.max > 1, true)
+	equals(t, h.max <= 3, true)
+}
+
+func TestParFilterClose(t *testing.T) {
+	before := runtime.NumGoroutine()
+	it := ParFilter(Range(0, 1000, 1), 4, isOdd)
+	equals(t, ToSlice(Take(it, 3)), []int{1, 3, 5})
+	Close(it)
+	equals(t, it.Next().IsNone(), true)
+	waitGoroutines(t, before)
+}
+
+func TestParFilterPanic(t *testing.T) {
+	defer func() {
+		equals(t, recover(), any("boom"))
+	}()
+	ToSlice(ParFilter(Range(0, 100, 1), 4, func(i int) bool {
+		if i == 10 {
+			panic("boom")
+		}
+		return true
+	}))
+	t.Fatal("expected panic")
+}
+
+func TestParForEach(t *testing.T) {
+	var total int64
+	err := ParForEach(context.Background(), Range(0, 100, 1), 4, func(_ context.Context, i int) error {
+		atomic.AddInt64(&total, int64(i))
+		return nil
+	})
+	equals(t, err, nil)
+	equals(t, total, int64(4950))
+}
+
+func TestParForEachError(t *testing.T) {
+	failure := errors.New("failure")
+	var processed, pulled int64
+	source := Map(Range(0, 100, 1), func(i int) int {
+		pulled++
+		return i
+	})
+	err := ParForEach(context.Background(), source, 1, func(_ context.Context, i int) error {
+		atomic.AddInt64(&processed, 1)
+		if i == 3 {
+			return failure
+		}
+		return nil
+	})
+	equals(t, err, failure)
+	equals(t, processed, int64(4))
+	equals(t, pulled <= 6, true)
+}
+
+func TestParForEachPanic(t *testing.T) {
+	before := runtime.NumGoroutine()
+	defer func() {
+		equals(t, recover(), any("boom"))
+		waitGoroutines(t, before)
+	}()
+	ParForEach(context.Background(), Range(0, 100, 1), 4, func(_ context.Context, i int) error {
+		if i == 10 {
+			panic("boom")
+		}
+		return nil
+	})
+	t.Fatal("expected panic")
+}
+
+func TestParForEachCancelAfterExhaustion(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	processed := make(chan int, 10)
+	source := Range(0, 10, 1)
+	// Cancel only once every value has been processed and the source is
+	// exhausted.
+	err := ParForEach(ctx, Func(func() Option[int] {
+		v := source.Next()
+		if v.IsNone() {
+			for i := 0; i < 10; i++ {
+				<-processed
+			}
+			cancel()
+		}
+		return v
+	}), 2, func(ctx context.Context, i int) error {
+		processed <- i
+		return nil
+	})
+	equals(t, err, nil)
+}
+
+func TestParForEachCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	var processed int64
+	err := ParForEach(ctx, Range(0, 100, 1), 2, func(ctx context.Context, i int) error {
+		if atomic.AddInt64(&processed, 1) == 5 {
+			cancel()
+		}
+		return nil
+	})
+	equals(t, err, context.Canceled)
+	equals(t, processed < 100, true)
+
+	err = ParForEach(ctx, Range(0, 100, 1), 2, func(ctx context.Context, i int) error {
+		t.Fatal("called after cancellation")
+		return nil
+	})
+	equals(t, err, context.Canceled)
+}
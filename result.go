@@ -0,0 +1,40 @@
+package iter
+
+// Result[T] represents either a value of type T or an error.
+type Result[T any] struct {
+	value T
+	err   error
+}
+
+// Ok returns a successful Result containing a value.
+func Ok[T any](v T) Result[T] {
+	return Result[T]{value: v}
+}
+
+// Err returns a failed Result containing an error.
+func Err[T any](err error) Result[T] {
+	return Result[T]{err: err}
+}
+
+// IsOk returns true if Result contains a value.
+func (res Result[T]) IsOk() bool {
+	return res.err == nil
+}
+
+// IsErr returns true if Result contains an error.
+func (res Result[T]) IsErr() bool {
+	return !res.IsOk()
+}
+
+// Unwrap extracts a value from Result. Panics if Result contains an error.
+func (res Result[T]) Unwrap() T {
+	if res.IsErr() {
+		panic("Attempted to unwrap a failed Result.")
+	}
+	return res.value
+}
+
+// Err returns the error contained in Result or nil if Result contains a value.
+func (res Result[T]) Err() error {
+	return res.err
+}
@@ -0,0 +1,325 @@
+package iter
+
+import (
+	"container/heap"
+	"sort"
+
+	"github.com/Soft/iter/constraint"
+)
+
+type sortedIter[T any] struct {
+	inner  Iterator[T]
+	sort   func([]T)
+	sorted Iterator[T]
+}
+
+// Sorted returns an Iterator adapter that yields the elements of the underlying
+// Iterator in ascending order. The underlying Iterator is collected into a slice
+// and sorted on the first call to Next, so the whole input is buffered.
+func Sorted[T constraint.Ordered](it Iterator[T]) Iterator[T] {
+	return &sortedIter[T]{
+		inner: it,
+		sort: func(s []T) {
+			sort.Slice(s, func(i, j int) bool {
+				return s[i] < s[j]
+			})
+		},
+	}
+}
+
+// SortedByFunc returns an Iterator adapter that yields the elements of the
+// underlying Iterator in the order determined by function less. The sort is
+// stable, so equal elements are yielded in the order they were encountered. The
+// underlying Iterator is collected into a slice and sorted on the first call to
+// Next, so the whole input is buffered.
+func SortedByFunc[T any](it Iterator[T], less func(a, b T) bool) Iterator[T] {
+	return &sortedIter[T]{
+		inner: it,
+		sort: func(s []T) {
+			sort.SliceStable(s, func(i, j int) bool {
+				return less(s[i], s[j])
+			})
+		},
+	}
+}
+
+func (it *sortedIter[T]) Next() Option[T] {
+	if it.sorted == nil {
+		s := ToSlice(it.inner)
+		it.sort(s)
+		it.sorted = Slice(s)
+		it.inner = nil
+	}
+	return it.sorted.Next()
+}
+
+type mergeSortedIter[T any] struct {
+	a    PeekableIterator[T]
+	b    PeekableIterator[T]
+	less func(T, T) bool
+}
+
+// MergeSorted returns an Iterator adapter that merges two Iterators sorted
+// according to function less into a single sorted Iterator. At most one element
+// from each Iterator is buffered. When elements compare equal, the one from a is
+// yielded first.
+func MergeSorted[T any](a, b Iterator[T], less func(a, b T) bool) Iterator[T] {
+	return &mergeSortedIter[T]{
+		a:    Peekable(a),
+		b:    Peekable(b),
+		less: less,
+	}
+}
+
+func (it *mergeSortedIter[T]) Next() Option[T] {
+	a, b := it.a.Peek(), it.b.Peek()
+	if a.IsNone() {
+		return it.b.Next()
+	}
+	if b.IsSome() && it.less(b.Unwrap(), a.Unwrap()) {
+		return it.b.Next()
+	}
+	return it.a.Next()
+}
+
+type mergeHead[T any] struct {
+	value T
+	index int
+}
+
+// mergeHeap is a heap of the head elements of the Iterators being merged. Ties
+// are broken by the position of the Iterator to keep the merge stable.
+type mergeHeap[T any] struct {
+	heads []mergeHead[T]
+	less  func(T, T) bool
+}
+
+func (h *mergeHeap[T]) Len() int {
+	return len(h.heads)
+}
+
+func (h *mergeHeap[T]) Less(i, j int) bool {
+	a, b := h.heads[i], h.heads[j]
+	if h.less(a.value, b.value) {
+		return true
+	}
+	if h.less(b.value, a.value) {
+		return false
+	}
+	return a.index < b.index
+}
+
+func (h *mergeHeap[T]) Swap(i, j int) {
+	h.heads[i], h.heads[j] = h.heads[j], h.heads[i]
+}
+
+func (h *mergeHeap[T]) Push(x any) {
+	h.heads = append(h.heads, x.(mergeHead[T]))
+}
+
+func (h *mergeHeap[T]) Pop() any {
+	last := h.heads[len(h.heads)-1]
+	h.heads = h.heads[:len(h.heads)-1]
+	return last
+}
+
+type kWayMergeIter[T any] struct {
+	its     []Iterator[T]
+	heap    mergeHeap[T]
+	started bool
+}
+
+// KWayMerge returns an Iterator adapter that merges any number of Iterators
+// sorted according to function less into a single sorted Iterator. The head
+// elements of the Iterators are kept in a heap, so yielding an element takes
+// logarithmic time in the number of Iterators. When elements compare equal, the
+// one from the Iterator given first is yielded first.
+func KWayMerge[T any](less func(a, b T) bool, its ...Iterator[T]) Iterator[T] {
+	return &kWayMergeIter[T]{
+		its: append([]Iterator[T](nil), its...),
+		heap: mergeHeap[T]{
+			heads: make([]mergeHead[T], 0, len(its)),
+			less:  less,
+		},
+	}
+}
+
+func (it *kWayMergeIter[T]) Next() Option[T] {
+	if !it.started {
+		it.started = true
+		for i, inner := range it.its {
+			if v := inner.Next(); v.IsSome() {
+				it.heap.heads = append(it.heap.heads, mergeHead[T]{v.Unwrap(), i})
+			}
+		}
+		heap.Init(&it.heap)
+	}
+	if it.heap.Len() == 0 {
+		return None[T]()
+	}
+	head := it.heap.heads[0]
+	if v := it.its[head.index].Next(); v.IsSome() {
+		it.heap.heads[0].value = v.Unwrap()
+		heap.Fix(&it.heap, 0)
+	} else {
+		heap.Pop(&it.heap)
+		it.its[head.index] = nil
+	}
+	return Some(head.value)
+}
+
+type sortedIntersectionIter[T any] struct {
+	a    PeekableIterator[T]
+	b    PeekableIterator[T]
+	less func(T, T) bool
+}
+
+// SortedIntersection returns an Iterator adapter that yields the elements
+// present in both of the sorted Iterators a and b. An element occurring
+// multiple times is yielded as many times as it occurs in the Iterator with
+// fewer occurrences of it.
+func SortedIntersection[T constraint.Ordered](a, b Iterator[T]) Iterator[T] {
+	return SortedIntersectionBy(a, b, func(a, b T) bool {
+		return a < b
+	})
+}
+
+// SortedIntersectionBy is like SortedIntersection but determines the order of
+// the elements using function less.
+func SortedIntersectionBy[T any](a, b Iterator[T], less func(a, b T) bool) Iterator[T] {
+	return &sortedIntersectionIter[T]{
+		a:    Peekable(a),
+		b:    Peekable(b),
+		less: less,
+	}
+}
+
+func (it *sortedIntersectionIter[T]) Next() Option[T] {
+	for {
+		a, b := it.a.Peek(), it.b.Peek()
+		if a.IsNone() || b.IsNone() {
+			return None[T]()
+		}
+		switch {
+		case it.less(a.Unwrap(), b.Unwrap()):
+			it.a.Next()
+		case it.less(b.Unwrap(), a.Unwrap()):
+			it.b.Next()
+		default:
+			it.b.Next()
+			return it.a.Next()
+		}
+	}
+}
+
+type sortedDifferenceIter[T any] struct {
+	a    PeekableIterator[T]
+	b    PeekableIterator[T]
+	less func(T, T) bool
+}
+
+// SortedDifference returns an Iterator adapter that yields the elements of the
+// sorted Iterator a that are not present in the sorted Iterator b. A single
+// occurrence of an element in b suppresses all equal elements of a.
+func SortedDifference[T any](a, b Iterator[T], less func(a, b T) bool) Iterator[T] {
+	return &sortedDifferenceIter[T]{
+		a:    Peekable(a),
+		b:    Peekable(b),
+		less: less,
+	}
+}
+
+func (it *sortedDifferenceIter[T]) Next() Option[T] {
+	for {
+		a := it.a.Peek()
+		if a.IsNone() {
+			return a
+		}
+		b := it.b.Peek()
+		switch {
+		case b.IsNone() || it.less(a.Unwrap(), b.Unwrap()):
+			return it.a.Next()
+		case it.less(b.Unwrap(), a.Unwrap()):
+			it.b.Next()
+		default:
+			// Keep the element of b around to suppress further equal elements.
+			it.a.Next()
+		}
+	}
+}
+
+// SortedUnion returns an Iterator adapter that yields each distinct element of
+// the sorted Iterators a and b once, in sorted order. Equal elements are
+// detected using ==, so equal elements within a single Iterator are also
+// collapsed.
+func SortedUnion[T comparable](a, b Iterator[T], less func(a, b T) bool) Iterator[T] {
+	return Dedup(MergeSorted(a, b, less))
+}
+
+// Side identifies which of two Iterators an element came from.
+type Side int
+
+const (
+	// SideA identifies the first of two Iterators.
+	SideA Side = iota
+	// SideB identifies the second of two Iterators.
+	SideB
+)
+
+// Sided[T] represents an element tagged with the Iterator it came from.
+type Sided[T any] struct {
+	Side  Side
+	Value T
+}
+
+type sortedSymmetricDifferenceIter[T any] struct {
+	a    PeekableIterator[T]
+	b    PeekableIterator[T]
+	less func(T, T) bool
+}
+
+// SortedSymmetricDifference returns an Iterator adapter that yields the
+// elements present in exactly one of the sorted Iterators a and b, in sorted
+// order. A single occurrence of an element in either Iterator suppresses all
+// equal elements of the other.
+func SortedSymmetricDifference[T any](a, b Iterator[T], less func(a, b T) bool) Iterator[T] {
+	return Map(SortedSymmetricDifferenceSided(a, b, less), func(v Sided[T]) T {
+		return v.Value
+	})
+}
+
+// SortedSymmetricDifferenceSided is like SortedSymmetricDifference but tags each
+// element with the Iterator it came from.
+func SortedSymmetricDifferenceSided[T any](a, b Iterator[T], less func(a, b T) bool) Iterator[Sided[T]] {
+	return &sortedSymmetricDifferenceIter[T]{
+		a:    Peekable(a),
+		b:    Peekable(b),
+		less: less,
+	}
+}
+
+// skipEqual consumes the elements of p equal to v.
+func (it *sortedSymmetricDifferenceIter[T]) skipEqual(p PeekableIterator[T], v T) {
+	for next := p.Peek(); next.IsSome() && !it.less(v, next.Unwrap()); next = p.Peek() {
+		p.Next()
+	}
+}
+
+func (it *sortedSymmetricDifferenceIter[T]) Next() Option[Sided[T]] {
+	for {
+		a, b := it.a.Peek(), it.b.Peek()
+		switch {
+		case a.IsNone() && b.IsNone():
+			return None[Sided[T]]()
+		case b.IsNone() || (a.IsSome() && it.less(a.Unwrap(), b.Unwrap())):
+			it.a.Next()
+			return Some(Sided[T]{SideA, a.Unwrap()})
+		case a.IsNone() || it.less(b.Unwrap(), a.Unwrap()):
+			it.b.Next()
+			return Some(Sided[T]{SideB, b.Unwrap()})
+		default:
+			it.skipEqual(it.a, a.Unwrap())
+			it.skipEqual(it.b, b.Unwrap())
+		}
+	}
+}
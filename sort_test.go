@@ -0,0 +1,193 @@
+package iter_test
+
+import (
+	"testing"
+
+	. "github.com/Soft/iter"
+	"github.com/Soft/iter/itertest"
+)
+
+func TestSorted(t *testing.T) {
+	itertest.AssertYields(t, Sorted(Range(0, 5, 1)), []int{0, 1, 2, 3, 4})
+	itertest.AssertYields(t, Sorted(Range(4, -1, -1)), []int{0, 1, 2, 3, 4})
+	itertest.AssertYields(t, Sorted(Slice([]int{3, 1, 3, 2, 1})), []int{1, 1, 2, 3, 3})
+	itertest.AssertYields(t, Sorted(Slice([]string{"b", "c", "a"})), []string{"a", "b", "c"})
+	itertest.AssertYields(t, Sorted(Empty[float64]()), nil)
+	counting := itertest.Counting(Slice([]int{2, 1}))
+	it := Sorted[int](counting)
+	equals(t, counting.Calls(), 0)
+	equals(t, ToSlice(Take(it, 1)), []int{1})
+}
+
+func TestSortedByFunc(t *testing.T) {
+	type record struct {
+		key   int
+		order int
+	}
+	records := []record{{3, 0}, {1, 1}, {2, 2}, {1, 3}, {3, 4}, {1, 5}}
+	itertest.AssertYields(
+		t,
+		SortedByFunc(Slice(records), func(a, b record) bool {
+			return a.key < b.key
+		}),
+		[]record{{1, 1}, {1, 3}, {1, 5}, {2, 2}, {3, 0}, {3, 4}},
+	)
+	itertest.AssertYields(t, SortedByFunc(Empty[int](), func(a, b int) bool { return a < b }), nil)
+}
+
+func TestMergeSorted(t *testing.T) {
+	less := func(a, b int) bool {
+		return a < b
+	}
+	itertest.AssertYields(
+		t,
+		MergeSorted(Slice([]int{1, 3, 5, 7}), Slice([]int{2, 3, 3, 8}), less),
+		[]int{1, 2, 3, 3, 3, 5, 7, 8},
+	)
+	itertest.AssertYields(t, MergeSorted(Empty[int](), Slice([]int{1, 2}), less), []int{1, 2})
+	itertest.AssertYields(t, MergeSorted(Slice([]int{1, 2}), Empty[int](), less), []int{1, 2})
+	itertest.AssertYields(t, MergeSorted(Empty[int](), Empty[int](), less), nil)
+	type tagged struct {
+		value int
+		side  string
+	}
+	byValue := func(a, b tagged) bool {
+		return a.value < b.value
+	}
+	itertest.AssertYields(
+		t,
+		MergeSorted(
+			Slice([]tagged{{1, "a"}, {2, "a"}}),
+			Slice([]tagged{{1, "b"}, {2, "b"}}),
+			byValue,
+		),
+		[]tagged{{1, "a"}, {1, "b"}, {2, "a"}, {2, "b"}},
+	)
+}
+
+func TestKWayMerge(t *testing.T) {
+	less := func(a, b int) bool {
+		return a < b
+	}
+	its := make([]Iterator[int], 5)
+	for i := range its {
+		its[i] = Range(i, 50, 5)
+	}
+	merged := ToSlice(KWayMerge(less, its...))
+	equals(t, len(merged), 50)
+	equals(t, All(Pairwise(Slice(merged)), func(p Pair[int, int]) bool {
+		return p.First <= p.Second
+	}), true)
+	itertest.AssertYields(
+		t,
+		KWayMerge(less, Empty[int](), Slice([]int{1, 4}), Empty[int](), Slice([]int{2, 3, 5})),
+		[]int{1, 2, 3, 4, 5},
+	)
+	itertest.AssertYields(t, KWayMerge(less), nil)
+	it := KWayMerge(less, resurrecting())
+	equals(t, it.Next(), Some(1))
+	itertest.AssertFused(t, it)
+}
+
+func TestKWayMergeStable(t *testing.T) {
+	type tagged struct {
+		value  int
+		source int
+	}
+	byValue := func(a, b tagged) bool {
+		return a.value < b.value
+	}
+	itertest.AssertYields(
+		t,
+		KWayMerge(
+			byValue,
+			Slice([]tagged{{1, 0}, {2, 0}}),
+			Slice([]tagged{{1, 1}, {2, 1}}),
+			Slice([]tagged{{0, 2}, {1, 2}}),
+		),
+		[]tagged{{0, 2}, {1, 0}, {1, 1}, {1, 2}, {2, 0}, {2, 1}},
+	)
+}
+
+func TestSortedIntersection(t *testing.T) {
+	itertest.AssertYields(t, SortedIntersection(Range(0, 10, 2), Range(0, 10, 3)), []int{0, 6})
+	itertest.AssertYields(t, SortedIntersection(Range(0, 5, 1), Range(5, 10, 1)), nil)
+	itertest.AssertYields(
+		t,
+		SortedIntersection(Slice([]int{1, 2, 2, 2, 3}), Slice([]int{2, 2, 3, 3})),
+		[]int{2, 2, 3},
+	)
+	itertest.AssertYields(t, SortedIntersection(Empty[int](), Range(0, 5, 1)), nil)
+	byLength := func(a, b string) bool {
+		return len(a) < len(b)
+	}
+	itertest.AssertYields(
+		t,
+		SortedIntersectionBy(Slice([]string{"a", "bb", "cccc"}), Slice([]string{"xx", "yyy", "zzzz"}), byLength),
+		[]string{"bb", "cccc"},
+	)
+}
+
+func TestSortedDifference(t *testing.T) {
+	less := func(a, b int) bool {
+		return a < b
+	}
+	itertest.AssertYields(t, SortedDifference(Range(0, 10, 1), Range(3, 15, 1), less), []int{0, 1, 2})
+	itertest.AssertYields(t, SortedDifference(Range(0, 10, 1), Range(0, 10, 2), less), []int{1, 3, 5, 7, 9})
+	itertest.AssertYields(t, SortedDifference(Range(0, 3, 1), Range(5, 10, 1), less), []int{0, 1, 2})
+	itertest.AssertYields(t, SortedDifference(Range(5, 8, 1), Range(0, 3, 1), less), []int{5, 6, 7})
+	itertest.AssertYields(
+		t,
+		SortedDifference(Slice([]int{1, 2, 2, 2, 3}), Slice([]int{2, 2, 2, 2}), less),
+		[]int{1, 3},
+	)
+	itertest.AssertYields(t, SortedDifference(Slice([]int{2, 2}), Slice([]int{2}), less), nil)
+	itertest.AssertYields(t, SortedDifference(Empty[int](), Range(0, 3, 1), less), nil)
+}
+
+func TestSortedUnion(t *testing.T) {
+	less := func(a, b int) bool {
+		return a < b
+	}
+	itertest.AssertYields(
+		t,
+		SortedUnion(Slice([]int{1, 2, 3, 5}), Slice([]int{1, 2, 4, 6}), less),
+		[]int{1, 2, 3, 4, 5, 6},
+	)
+	itertest.AssertYields(
+		t,
+		SortedUnion(Slice([]int{1, 1, 2, 3}), Slice([]int{1, 1, 2, 3}), less),
+		ToSlice(Dedup(Slice([]int{1, 1, 2, 3}))),
+	)
+	itertest.AssertYields(t, SortedUnion(Empty[int](), Slice([]int{1, 1}), less), []int{1})
+	itertest.AssertYields(t, SortedUnion(Empty[int](), Empty[int](), less), nil)
+}
+
+func TestSortedSymmetricDifference(t *testing.T) {
+	less := func(a, b int) bool {
+		return a < b
+	}
+	itertest.AssertYields(
+		t,
+		SortedSymmetricDifference(Slice([]int{1, 2, 4, 6, 7}), Slice([]int{2, 3, 5, 6, 8}), less),
+		[]int{1, 3, 4, 5, 7, 8},
+	)
+	itertest.AssertYields(
+		t,
+		SortedSymmetricDifference(Slice([]int{1, 2, 2, 3}), Slice([]int{1, 2, 3, 3}), less),
+		nil,
+	)
+	itertest.AssertYields(t, SortedSymmetricDifference(Range(0, 3, 1), Empty[int](), less), []int{0, 1, 2})
+	itertest.AssertYields(t, SortedSymmetricDifference(Empty[int](), Range(0, 3, 1), less), []int{0, 1, 2})
+}
+
+func TestSortedSymmetricDifferenceSided(t *testing.T) {
+	less := func(a, b string) bool {
+		return a < b
+	}
+	itertest.AssertYields(
+		t,
+		SortedSymmetricDifferenceSided(Slice([]string{"a", "b", "d"}), Slice([]string{"b", "c", "e"}), less),
+		[]Sided[string]{{SideA, "a"}, {SideB, "c"}, {SideA, "d"}, {SideB, "e"}},
+	)
+}
@@ -0,0 +1,268 @@
+package iter
+
+import "github.com/Soft/iter/constraint"
+
+type unfoldIter[S, T any] struct {
+	state S
+	fn    func(S) Option[Pair[T, S]]
+	done  bool
+}
+
+// Unfold returns an Iterator that generates elements from a state. Function fn
+// is called with the current state and returns the next element together with
+// the next state, or None to end the Iterator. Function fn is not called again
+// once it has returned None.
+func Unfold[S, T any](seed S, fn func(S) Option[Pair[T, S]]) Iterator[T] {
+	return &unfoldIter[S, T]{
+		state: seed,
+		fn:    fn,
+	}
+}
+
+func (it *unfoldIter[S, T]) Next() Option[T] {
+	if it.done {
+		return None[T]()
+	}
+	v := it.fn(it.state)
+	if v.IsNone() {
+		it.done = true
+		return None[T]()
+	}
+	next := v.Unwrap()
+	it.state = next.Second
+	return Some(next.First)
+}
+
+type iterateIter[T any] struct {
+	value   T
+	fn      func(T) T
+	started bool
+}
+
+// Iterate returns an infinite Iterator that yields initial followed by the
+// results of applying function fn repeatedly to the previously yielded value.
+// Function fn is called only when the next value is requested.
+func Iterate[T any](initial T, fn func(T) T) Iterator[T] {
+	return &iterateIter[T]{
+		value: initial,
+		fn:    fn,
+	}
+}
+
+func (it *iterateIter[T]) Next() Option[T] {
+	if it.started {
+		it.value = it.fn(it.value)
+	}
+	it.started = true
+	return Some(it.value)
+}
+
+type rangeOfIter[T constraint.Integer] struct {
+	next, end, step T
+	done            bool
+}
+
+// RangeOf returns an Iterator over a range of integers of any integer type. It
+// behaves like Range and does not overflow near the limits of T.
+func RangeOf[T constraint.Integer](start, end, step T) Iterator[T] {
+	return &rangeOfIter[T]{
+		next: start,
+		end:  end,
+		step: step,
+	}
+}
+
+// distance returns the distance between the next value and the end of the range
+// and the magnitude of the step. Differences are computed in uint64 to avoid
+// overflowing T.
+func (it *rangeOfIter[T]) distance() (uint64, uint64) {
+	if it.step > 0 {
+		return uint64(it.end) - uint64(it.next), uint64(it.step)
+	}
+	return uint64(it.next) - uint64(it.end), uint64(0) - uint64(it.step)
+}
+
+func (it *rangeOfIter[T]) remaining() bool {
+	if it.done {
+		return false
+	}
+	if it.step > 0 {
+		return it.next < it.end
+	}
+	return it.next > it.end
+}
+
+func (it *rangeOfIter[T]) Next() Option[T] {
+	if !it.remaining() {
+		return None[T]()
+	}
+	v := it.next
+	if it.step != 0 {
+		if d, step := it.distance(); d <= step {
+			it.done = true
+		} else {
+			it.next += it.step
+		}
+	}
+	return Some(v)
+}
+
+func (it *rangeOfIter[T]) size() Option[uint] {
+	switch {
+	case !it.remaining():
+		return Some[uint](0)
+	case it.step == 0:
+		// Zero step ranges are infinite.
+		return None[uint]()
+	}
+	d, step := it.distance()
+	n := d / step
+	if d%step != 0 {
+		n++
+	}
+	return Some(uint(n))
+}
+
+type linspaceIter struct {
+	start, stop float64
+	n, i        uint
+}
+
+// Linspace returns an Iterator yielding n evenly spaced values from start to
+// stop, including both endpoints. Each value is computed from its position
+// rather than by accumulating steps. If n is one, only start is yielded.
+func Linspace(start, stop float64, n uint) Iterator[float64] {
+	return &linspaceIter{
+		start: start,
+		stop:  stop,
+		n:     n,
+	}
+}
+
+func (it *linspaceIter) Next() Option[float64] {
+	switch {
+	case it.i >= it.n:
+		return None[float64]()
+	case it.i == 0:
+		it.i++
+		return Some(it.start)
+	case it.i == it.n-1:
+		it.i++
+		return Some(it.stop)
+	}
+	v := it.start + float64(it.i)*(it.stop-it.start)/float64(it.n-1)
+	it.i++
+	return Some(v)
+}
+
+func (it *linspaceIter) Len() uint {
+	return it.n - it.i
+}
+
+type counterIter[T constraint.Integer] struct {
+	next, step T
+}
+
+// Counter returns an infinite Iterator that counts from start by step. The
+// count wraps around on overflow like Go integer arithmetic does.
+func Counter[T constraint.Integer](start, step T) Iterator[T] {
+	return &counterIter[T]{
+		next: start,
+		step: step,
+	}
+}
+
+func (it *counterIter[T]) Next() Option[T] {
+	v := it.next
+	it.next += it.step
+	return Some(v)
+}
+
+type repeatWithIter[T any] struct {
+	fn func() T
+}
+
+// RepeatWith returns an infinite Iterator that yields the values returned by
+// calling function fn each time a value is requested.
+func RepeatWith[T any](fn func() T) Iterator[T] {
+	return &repeatWithIter[T]{fn: fn}
+}
+
+func (it *repeatWithIter[T]) Next() Option[T] {
+	return Some(it.fn())
+}
+
+type repeatNIter[T any] struct {
+	value T
+	n     uint
+}
+
+// RepeatN returns an Iterator that yields value n times.
+func RepeatN[T any](value T, n uint) Iterator[T] {
+	return &repeatNIter[T]{
+		value: value,
+		n:     n,
+	}
+}
+
+func (it *repeatNIter[T]) Next() Option[T] {
+	if it.n == 0 {
+		return None[T]()
+	}
+	it.n--
+	return Some(it.value)
+}
+
+func (it *repeatNIter[T]) Len() uint {
+	return it.n
+}
+
+func (it *repeatNIter[T]) Clone() Iterator[T] {
+	clone := *it
+	return &clone
+}
+
+type lazyIter[T any] struct {
+	build func() Iterator[T]
+	inner Iterator[T]
+}
+
+// Lazy returns an Iterator that calls function build to construct the
+// underlying Iterator on the first call to Next and then yields its elements.
+func Lazy[T any](build func() Iterator[T]) Iterator[T] {
+	return &lazyIter[T]{build: build}
+}
+
+func (it *lazyIter[T]) Next() Option[T] {
+	if it.inner == nil {
+		it.inner = it.build()
+		it.build = nil
+	}
+	return it.inner.Next()
+}
+
+type onceWithIter[T any] struct {
+	fn func() T
+}
+
+// OnceWith returns an Iterator that yields the value returned by function fn
+// once. Function fn is called on the first call to Next and never again.
+func OnceWith[T any](fn func() T) Iterator[T] {
+	return &onceWithIter[T]{fn: fn}
+}
+
+func (it *onceWithIter[T]) Next() Option[T] {
+	if it.fn == nil {
+		return None[T]()
+	}
+	v := it.fn()
+	it.fn = nil
+	return Some(v)
+}
+
+func (it *onceWithIter[T]) Len() uint {
+	if it.fn == nil {
+		return 0
+	}
+	return 1
+}
@@ -0,0 +1,145 @@
+package iter_test
+
+import (
+	"bytes"
+	"testing"
+
+	. "github.com/Soft/iter"
+	"github.com/Soft/iter/itertest"
+)
+
+func TestUnfold(t *testing.T) {
+	fibonacci := Unfold(Pair[int, int]{0, 1}, func(s Pair[int, int]) Option[Pair[int, Pair[int, int]]] {
+		return Some(Pair[int, Pair[int, int]]{s.First, Pair[int, int]{s.Second, s.First + s.Second}})
+	})
+	equals(t, ToSlice(Take(fibonacci, 8)), []int{0, 1, 1, 2, 3, 5, 8, 13})
+	calls := 0
+	countdown := Unfold(3, func(n int) Option[Pair[int, int]] {
+		calls++
+		if n == 0 {
+			return None[Pair[int, int]]()
+		}
+		return Some(Pair[int, int]{n, n - 1})
+	})
+	itertest.AssertYields(t, countdown, []int{3, 2, 1})
+	itertest.AssertFused(t, countdown)
+	equals(t, calls, 4)
+}
+
+func TestIterate(t *testing.T) {
+	calls := 0
+	double := func(i int) int {
+		calls++
+		return i * 2
+	}
+	it := Iterate(1, double)
+	equals(t, it.Next(), Some(1))
+	equals(t, calls, 0)
+	equals(t, ToSlice(Take(it, 4)), []int{2, 4, 8, 16})
+	equals(t, calls, 4)
+	equals(t, ToSlice(Take(Iterate(1, double), 5)), []int{1, 2, 4, 8, 16})
+	equals(t, ToSlice(TakeWhile(Iterate(100, func(i int) int { return i / 3 }), func(i int) bool { return i > 0 })), []int{100, 33, 11, 3, 1})
+}
+
+func TestRangeOf(t *testing.T) {
+	itertest.AssertYields(t, RangeOf[int64](0, 10, 3), []int64{0, 3, 6, 9})
+	itertest.AssertYields(t, RangeOf[int8](10, 0, -4), []int8{10, 6, 2})
+	itertest.AssertYields(t, RangeOf[uint32](5, 8, 1), []uint32{5, 6, 7})
+	itertest.AssertYields(t, RangeOf[uint8](250, 255, 2), []uint8{250, 252, 254})
+	itertest.AssertYields(t, RangeOf[uint8](0, 255, 200), []uint8{0, 200})
+	itertest.AssertYields(t, RangeOf[int8](100, -128, -100), []int8{100, 0, -100})
+	itertest.AssertYields(t, RangeOf[int8](-128, 127, 127), []int8{-128, -1, 126})
+	itertest.AssertYields(t, RangeOf[uint](5, 1, 1), nil)
+	itertest.AssertYields(t, RangeOf(0, 5, 0), nil)
+	equals(t, ToSlice(Take(RangeOf(5, 0, 0), 2)), []int{5, 5})
+	for _, r := range [][3]int{{0, 10, 3}, {0, 9, 3}, {10, 0, -3}, {0, 0, 1}, {5, 0, 1}} {
+		equals(t, ToSlice(RangeOf(r[0], r[1], r[2])), ToSlice(Range(r[0], r[1], r[2])))
+		equals(t, Len(RangeOf(r[0], r[1], r[2])), Len(Range(r[0], r[1], r[2])))
+	}
+	equals(t, Len(RangeOf(5, 0, 0)), None[uint]())
+}
+
+func TestLinspace(t *testing.T) {
+	itertest.AssertYields(t, Linspace(0, 1, 5), []float64{0, 0.25, 0.5, 0.75, 1})
+	itertest.AssertYields(t, Linspace(1, 0, 3), []float64{1, 0.5, 0})
+	itertest.AssertYields(t, Linspace(2, 3, 1), []float64{2})
+	itertest.AssertYields(t, Linspace(2, 3, 0), nil)
+	points := ToSlice(Linspace(0.1, 0.7, 7))
+	equals(t, points[0], 0.1)
+	equals(t, points[6], 0.7)
+	equals(t, Len(Linspace(0.1, 0.7, 7)), Some[uint](7))
+}
+
+func TestCounter(t *testing.T) {
+	equals(t, ToSlice(Take(Counter(0, 1), 3)), []int{0, 1, 2})
+	equals(t, ToSlice(Take(Counter(10, -5), 3)), []int{10, 5, 0})
+	equals(t, ToSlice(Take(Counter[uint8](254, 1), 3)), []uint8{254, 255, 0})
+	equals(
+		t,
+		ToSlice(Zip[int, string](Counter(1, 1), Slice([]string{"a", "b"}))),
+		[]Pair[int, string]{{1, "a"}, {2, "b"}},
+	)
+}
+
+func TestRepeatWith(t *testing.T) {
+	buffers := ToSlice(Take(RepeatWith(func() *bytes.Buffer {
+		return new(bytes.Buffer)
+	}), 3))
+	equals(t, len(buffers), 3)
+	equals(t, buffers[0] != buffers[1] && buffers[1] != buffers[2] && buffers[0] != buffers[2], true)
+	n := 0
+	equals(t, ToSlice(Take(RepeatWith(func() int {
+		n++
+		return n
+	}), 3)), []int{1, 2, 3})
+}
+
+func TestRepeatN(t *testing.T) {
+	itertest.AssertYields(t, RepeatN("a", 0), nil)
+	itertest.AssertYields(t, RepeatN("a", 1), []string{"a"})
+	it := RepeatN("a", 4)
+	equals(t, Len(it), Some[uint](4))
+	equals(t, it.Next(), Some("a"))
+	equals(t, Len(it), Some[uint](3))
+	clone := Clone(it).Unwrap()
+	itertest.AssertYields(t, it, []string{"a", "a", "a"})
+	itertest.AssertFused(t, it)
+	equals(t, Len(clone), Some[uint](3))
+	equals(t, cap(ToSlice(RepeatN(1, 5))), 5)
+}
+
+func TestLazy(t *testing.T) {
+	built := false
+	it := Lazy(func() Iterator[int] {
+		built = true
+		return Range(0, 3, 1)
+	})
+	equals(t, built, false)
+	equals(t, it.Next(), Some(0))
+	equals(t, built, true)
+	itertest.AssertYields(t, it, []int{1, 2})
+	built = false
+	pipeline := ChainAll(Range(0, 2, 1), Lazy(func() Iterator[int] {
+		built = true
+		return Range(2, 4, 1)
+	}))
+	equals(t, ToSlice(Take(pipeline, 2)), []int{0, 1})
+	equals(t, built, false)
+}
+
+func TestOnceWith(t *testing.T) {
+	calls := 0
+	footer := func() string {
+		calls++
+		return "footer"
+	}
+	it := OnceWith(footer)
+	equals(t, calls, 0)
+	equals(t, Len(it), Some[uint](1))
+	itertest.AssertYields(t, it, []string{"footer"})
+	itertest.AssertFused(t, it)
+	equals(t, calls, 1)
+	calls = 0
+	equals(t, ToSlice(Take(Chain(Slice([]string{"a", "b"}), OnceWith(footer)), 2)), []string{"a", "b"})
+	equals(t, calls, 0)
+}
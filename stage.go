@@ -0,0 +1,67 @@
+package iter
+
+// Stage[T, R] represents a reusable step of an Iterator pipeline transforming
+// an Iterator yielding elements of type T into one yielding elements of type R.
+type Stage[T, R any] func(Iterator[T]) Iterator[R]
+
+// Pipe applies stages to an Iterator in order.
+func Pipe[T any](it Iterator[T], stages ...Stage[T, T]) Iterator[T] {
+	for _, stage := range stages {
+		it = stage(it)
+	}
+	return it
+}
+
+// Compose2 returns a Stage applying first and then second.
+func Compose2[A, B, C any](first Stage[A, B], second Stage[B, C]) Stage[A, C] {
+	return func(it Iterator[A]) Iterator[C] {
+		return second(first(it))
+	}
+}
+
+// Compose3 returns a Stage applying first, second and then third.
+func Compose3[A, B, C, D any](first Stage[A, B], second Stage[B, C], third Stage[C, D]) Stage[A, D] {
+	return Compose2(Compose2(first, second), third)
+}
+
+// MapStage returns a Stage applying Map with fn.
+func MapStage[T, R any](fn func(T) R) Stage[T, R] {
+	return func(it Iterator[T]) Iterator[R] {
+		return Map(it, fn)
+	}
+}
+
+// FilterStage returns a Stage applying Filter with pred.
+func FilterStage[T any](pred func(T) bool) Stage[T, T] {
+	return func(it Iterator[T]) Iterator[T] {
+		return Filter(it, pred)
+	}
+}
+
+// TakeStage returns a Stage applying Take with n.
+func TakeStage[T any](n uint) Stage[T, T] {
+	return func(it Iterator[T]) Iterator[T] {
+		return Take(it, n)
+	}
+}
+
+// TakeWhileStage returns a Stage applying TakeWhile with pred.
+func TakeWhileStage[T any](pred func(T) bool) Stage[T, T] {
+	return func(it Iterator[T]) Iterator[T] {
+		return TakeWhile(it, pred)
+	}
+}
+
+// DropStage returns a Stage applying Drop with n.
+func DropStage[T any](n uint) Stage[T, T] {
+	return func(it Iterator[T]) Iterator[T] {
+		return Drop(it, n)
+	}
+}
+
+// DropWhileStage returns a Stage applying DropWhile with pred.
+func DropWhileStage[T any](pred func(T) bool) Stage[T, T] {
+	return func(it Iterator[T]) Iterator[T] {
+		return DropWhile(it, pred)
+	}
+}
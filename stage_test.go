@@ -0,0 +1,46 @@
+package iter_test
+
+import (
+	"strconv"
+	"testing"
+
+	. "github.com/Soft/iter"
+)
+
+func TestPipe(t *testing.T) {
+	positive := func(i int) bool {
+		return i > 0
+	}
+	equals(t, ToSlice(Pipe(Range(0, 3, 1))), []int{0, 1, 2})
+	pipeline := func(it Iterator[int]) Iterator[int] {
+		return Pipe(it, FilterStage(positive), FilterStage(isOdd), TakeStage[int](3))
+	}
+	sources := [][]int{
+		{-3, -1, 1, 2, 3, 5, 7, 9},
+		{},
+		{2, 4, 6, 1},
+	}
+	for _, source := range sources {
+		equals(
+			t,
+			ToSlice(pipeline(Slice(source))),
+			ToSlice(Take(Filter(Filter(Slice(source), positive), isOdd), 3)),
+		)
+	}
+}
+
+func TestCompose(t *testing.T) {
+	render := Compose3(
+		DropWhileStage(func(i int) bool {
+			return i < 2
+		}),
+		MapStage(square),
+		MapStage(strconv.Itoa),
+	)
+	equals(t, ToSlice(render(Range(0, 5, 1))), []string{"4", "9", "16"})
+	equals(t, ToSlice(render(Range(3, 5, 1))), []string{"9", "16"})
+	limit := Compose2(TakeWhileStage(func(i int) bool {
+		return i < 3
+	}), DropStage[int](1))
+	equals(t, ToSlice(limit(Range(0, 10, 1))), []int{1, 2})
+}
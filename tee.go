@@ -0,0 +1,90 @@
+package iter
+
+import "sync"
+
+// teeBuffer holds the elements of an Iterator shared between multiple readers.
+// Elements are dropped from the buffer once every reader has yielded them.
+type teeBuffer[T any] struct {
+	mu      sync.Mutex
+	inner   Iterator[T]
+	buf     []T
+	start   uint
+	cursors []uint
+	done    bool
+}
+
+type teeIter[T any] struct {
+	shared *teeBuffer[T]
+	reader int
+}
+
+// Tee returns two Iterators that both yield the elements of the underlying
+// Iterator. Elements are buffered until both of the Iterators have yielded
+// them, so consuming one Iterator far ahead of the other grows the buffer. The
+// returned Iterators may be used from different goroutines.
+func Tee[T any](it Iterator[T]) (Iterator[T], Iterator[T]) {
+	its := TeeN(it, 2)
+	return its[0], its[1]
+}
+
+// TeeN returns n Iterators that all yield the elements of the underlying
+// Iterator. Elements are buffered until every one of the Iterators has yielded
+// them, so the size of the buffer depends on how far behind the slowest
+// Iterator is. The returned Iterators may be used from different goroutines.
+func TeeN[T any](it Iterator[T], n uint) []Iterator[T] {
+	shared := &teeBuffer[T]{
+		inner:   it,
+		cursors: make([]uint, n),
+	}
+	its := make([]Iterator[T], n)
+	for i := range its {
+		its[i] = &teeIter[T]{shared, i}
+	}
+	return its
+}
+
+func (it *teeIter[T]) Next() Option[T] {
+	b := it.shared
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	pos := b.cursors[it.reader]
+	var v T
+	if i := pos - b.start; i < uint(len(b.buf)) {
+		v = b.buf[i]
+	} else {
+		if b.done {
+			return None[T]()
+		}
+		next := b.inner.Next()
+		if next.IsNone() {
+			b.done = true
+			b.inner = nil
+			return next
+		}
+		v = next.Unwrap()
+		b.buf = append(b.buf, v)
+	}
+	b.cursors[it.reader]++
+	b.trim()
+	return Some(v)
+}
+
+// trim drops the elements that every reader has already yielded.
+func (b *teeBuffer[T]) trim() {
+	lowest := b.cursors[0]
+	for _, c := range b.cursors[1:] {
+		if c < lowest {
+			lowest = c
+		}
+	}
+	n := lowest - b.start
+	if n == 0 {
+		return
+	}
+	var zero T
+	for i := uint(0); i < n; i++ {
+		b.buf[i] = zero
+	}
+	b.buf = b.buf[n:]
+	b.start = lowest
+}
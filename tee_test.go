@@ -0,0 +1,55 @@
+package iter_test
+
+import (
+	"sync"
+	"testing"
+
+	. "github.com/Soft/iter"
+	"github.com/Soft/iter/itertest"
+)
+
+func TestTee(t *testing.T) {
+	ch := make(chan int, 5)
+	for i := 0; i < 5; i++ {
+		ch <- i
+	}
+	close(ch)
+	a, b := Tee(Chan(ch))
+	equals(t, a.Next(), Some(0))
+	equals(t, a.Next(), Some(1))
+	itertest.AssertYields(t, b, []int{0, 1, 2, 3, 4})
+	itertest.AssertYields(t, a, []int{2, 3, 4})
+	itertest.AssertFused(t, a)
+	itertest.AssertFused(t, b)
+}
+
+func TestTeeConcurrent(t *testing.T) {
+	a, b := Tee(Range(0, 10000, 1))
+	var wg sync.WaitGroup
+	results := make([][]int, 2)
+	for i, it := range []Iterator[int]{a, b} {
+		wg.Add(1)
+		go func(i int, it Iterator[int]) {
+			defer wg.Done()
+			results[i] = ToSlice(it)
+		}(i, it)
+	}
+	wg.Wait()
+	want := ToSlice(Range(0, 10000, 1))
+	equals(t, results[0], want)
+	equals(t, results[1], want)
+}
+
+func TestTeeN(t *testing.T) {
+	counting := itertest.Counting(Range(0, 5, 1))
+	its := TeeN[int](counting, 3)
+	equals(t, len(its), 3)
+	itertest.AssertYields(t, its[0], []int{0, 1, 2, 3, 4})
+	equals(t, its[1].Next(), Some(0))
+	itertest.AssertYields(t, its[2], []int{0, 1, 2, 3, 4})
+	itertest.AssertYields(t, its[1], []int{1, 2, 3, 4})
+	equals(t, counting.Calls(), 6)
+	equals(t, len(TeeN(Range(0, 5, 1), 0)), 0)
+	single := TeeN(Range(0, 3, 1), 1)
+	itertest.AssertYields(t, single[0], []int{0, 1, 2})
+}
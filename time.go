@@ -0,0 +1,203 @@
+package iter
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock provides the current time and a way to wait for time to pass. It allows
+// replacing the real clock in time dependent adapters, such as in tests.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+	// Sleep pauses the current goroutine for at least the duration d.
+	Sleep(d time.Duration)
+}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+func (realClock) Sleep(d time.Duration) {
+	time.Sleep(d)
+}
+
+type throttleIter[T any] struct {
+	inner   Iterator[T]
+	every   time.Duration
+	clock   Clock
+	last    time.Time
+	started bool
+}
+
+// Throttle returns an Iterator adapter that yields the elements of the
+// underlying Iterator unchanged but sleeps as needed so that consecutive
+// elements are yielded at least every apart. The first element is yielded
+// without delay, and so is None.
+func Throttle[T any](it Iterator[T], every time.Duration) Iterator[T] {
+	return ThrottleWith(it, every, realClock{})
+}
+
+// ThrottleWith is like Throttle but uses clock for measuring and waiting for
+// time to pass.
+func ThrottleWith[T any](it Iterator[T], every time.Duration, clock Clock) Iterator[T] {
+	return &throttleIter[T]{
+		inner: it,
+		every: every,
+		clock: clock,
+	}
+}
+
+func (it *throttleIter[T]) Next() Option[T] {
+	v := it.inner.Next()
+	if v.IsNone() {
+		return v
+	}
+	if it.started {
+		if wait := it.last.Add(it.every).Sub(it.clock.Now()); wait > 0 {
+			it.clock.Sleep(wait)
+		}
+	}
+	it.started = true
+	it.last = it.clock.Now()
+	return v
+}
+
+type debounceIter[T any] struct {
+	ch    <-chan T
+	quiet time.Duration
+	done  chan struct{}
+	once  sync.Once
+}
+
+// Debounce returns an Iterator adapter that collapses bursts of elements of the
+// underlying Iterator, yielding only the latest element once no new elements
+// have arrived for the duration quiet. The element pending when the underlying
+// Iterator is exhausted is yielded without waiting. The underlying Iterator is
+// consumed on a new goroutine, which makes Debounce useful for Iterators that
+// block, such as those returned by Chan. The returned Iterator implements
+// Closer.
+func Debounce[T any](it Iterator[T], quiet time.Duration) Iterator[T] {
+	done := make(chan struct{})
+	return &debounceIter[T]{
+		ch:    produce(it, 0, done),
+		quiet: quiet,
+		done:  done,
+	}
+}
+
+func (it *debounceIter[T]) Next() Option[T] {
+	if closed(it.done) {
+		return None[T]()
+	}
+	var pending T
+	select {
+	case v, ok := <-it.ch:
+		if !ok {
+			return None[T]()
+		}
+		pending = v
+	case <-it.done:
+		return None[T]()
+	}
+	timer := time.NewTimer(it.quiet)
+	defer timer.Stop()
+	for {
+		select {
+		case v, ok := <-it.ch:
+			if !ok {
+				return Some(pending)
+			}
+			pending = v
+			if !timer.Stop() {
+				<-timer.C
+			}
+			timer.Reset(it.quiet)
+		case <-timer.C:
+			return Some(pending)
+		case <-it.done:
+			return None[T]()
+		}
+	}
+}
+
+func (it *debounceIter[T]) Close() {
+	it.once.Do(func() {
+		close(it.done)
+	})
+}
+
+type timeoutIter[T any] struct {
+	requests chan struct{}
+	results  chan outcome[Option[T]]
+	d        time.Duration
+	done     chan struct{}
+	once     sync.Once
+	waiting  bool
+	finished bool
+}
+
+// Timeout returns an Iterator adapter that yields None if the underlying
+// Iterator does not yield an element within the duration d. The underlying
+// Iterator is called on a new goroutine. An element that arrives after its
+// request timed out is yielded by the next call to Next. A panic in the
+// underlying Iterator is propagated to the caller of Next. The returned
+// Iterator implements Closer; after Close the goroutine exits as soon as any
+// call to the underlying Iterator in progress returns.
+func Timeout[T any](it Iterator[T], d time.Duration) Iterator[T] {
+	t := &timeoutIter[T]{
+		requests: make(chan struct{}, 1),
+		results:  make(chan outcome[Option[T]], 1),
+		d:        d,
+		done:     make(chan struct{}),
+	}
+	go func() {
+		for {
+			select {
+			case <-t.requests:
+			case <-t.done:
+				return
+			}
+			o := protect(it.Next)
+			t.results <- o
+			if o.panicked || o.value.IsNone() {
+				return
+			}
+		}
+	}()
+	return t
+}
+
+func (it *timeoutIter[T]) Next() Option[T] {
+	if it.finished || closed(it.done) {
+		return None[T]()
+	}
+	if !it.waiting {
+		it.requests <- struct{}{}
+		it.waiting = true
+	}
+	timer := time.NewTimer(it.d)
+	defer timer.Stop()
+	select {
+	case o := <-it.results:
+		it.waiting = false
+		if o.panicked {
+			it.Close()
+			panic(o.recovered)
+		}
+		it.finished = o.value.IsNone()
+		return o.value
+	case <-timer.C:
+		return None[T]()
+	case <-it.done:
+		return None[T]()
+	}
+}
+
+func (it *timeoutIter[T]) Close() {
+	it.once.Do(func() {
+		close(it.done)
+	})
+}
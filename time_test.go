@@ -0,0 +1,120 @@
+package iter_test
+
+import (
+	"runtime"
+	"testing"
+	"time"
+
+	. "github.com/Soft/iter"
+	"github.com/Soft/iter/itertest"
+)
+
+// fakeClock is a Clock that only advances when slept on or advanced
+// explicitly.
+type fakeClock struct {
+	now    time.Time
+	sleeps []time.Duration
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func (c *fakeClock) Sleep(d time.Duration) {
+	c.sleeps = append(c.sleeps, d)
+	c.now = c.now.Add(d)
+}
+
+func (c *fakeClock) advance(d time.Duration) {
+	c.now = c.now.Add(d)
+}
+
+func TestThrottle(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	it := ThrottleWith(Range(0, 4, 1), time.Second, clock)
+	equals(t, it.Next(), Some(0))
+	equals(t, it.Next(), Some(1))
+	clock.advance(300 * time.Millisecond)
+	equals(t, it.Next(), Some(2))
+	clock.advance(2 * time.Second)
+	equals(t, it.Next(), Some(3))
+	itertest.AssertFused(t, it)
+	equals(t, clock.sleeps, []time.Duration{time.Second, 700 * time.Millisecond})
+}
+
+func TestThrottleRealClock(t *testing.T) {
+	start := time.Now()
+	itertest.AssertYields(t, Throttle(Range(0, 3, 1), 10*time.Millisecond), []int{0, 1, 2})
+	equals(t, time.Since(start) >= 20*time.Millisecond, true)
+}
+
+func TestDebounce(t *testing.T) {
+	ch := make(chan int, 3)
+	ch <- 1
+	ch <- 2
+	ch <- 3
+	it := Debounce(Chan(ch), 50*time.Millisecond)
+	equals(t, it.Next(), Some(3))
+	ch <- 4
+	ch <- 5
+	close(ch)
+	equals(t, it.Next(), Some(5))
+	itertest.AssertFused(t, it)
+}
+
+func TestDebounceQuiet(t *testing.T) {
+	ch := make(chan int)
+	go func() {
+		defer close(ch)
+		ch <- 1
+		time.Sleep(200 * time.Millisecond)
+		ch <- 2
+	}()
+	itertest.AssertYields(t, Debounce(Chan(ch), 20*time.Millisecond), []int{1, 2})
+}
+
+func TestDebounceClose(t *testing.T) {
+	before := runtime.NumGoroutine()
+	it := Debounce(Repeat(1), time.Millisecond)
+	Close(it)
+	equals(t, it.Next().IsNone(), true)
+	waitGoroutines(t, before)
+}
+
+func TestTimeout(t *testing.T) {
+	before := runtime.NumGoroutine()
+	ch := make(chan int)
+	it := Timeout(Chan(ch), 20*time.Millisecond)
+	start := time.Now()
+	equals(t, it.Next(), None[int]())
+	equals(t, time.Since(start) >= 20*time.Millisecond, true)
+	go func() {
+		ch <- 1
+		close(ch)
+	}()
+	equals(t, it.Next(), Some(1))
+	itertest.AssertFused(t, it)
+	waitGoroutines(t, before)
+}
+
+func TestTimeoutClose(t *testing.T) {
+	before := runtime.NumGoroutine()
+	ch := make(chan int)
+	it := Timeout(Chan(ch), time.Millisecond)
+	equals(t, it.Next(), None[int]())
+	Close(it)
+	equals(t, it.Next(), None[int]())
+	close(ch)
+	waitGoroutines(t, before)
+}
+
+func TestTimeoutPanic(t *testing.T) {
+	before := runtime.NumGoroutine()
+	defer func() {
+		equals(t, recover(), any("boom"))
+		waitGoroutines(t, before)
+	}()
+	Timeout(Func(func() Option[int] {
+		panic("boom")
+	}), time.Second).Next()
+}
@@ -0,0 +1,14 @@
+package iter
+
+// Pair[A, B] represents a pair of values.
+type Pair[A, B any] struct {
+	First  A
+	Second B
+}
+
+// Triple[A, B, C] represents a triple of values.
+type Triple[A, B, C any] struct {
+	First  A
+	Second B
+	Third  C
+}
@@ -0,0 +1,161 @@
+package iter
+
+type zipIter[A, B any] struct {
+	first  Iterator[A]
+	second Iterator[B]
+	done   bool
+}
+
+// Zip returns an Iterator adapter that yields pairs of elements from two
+// iterators until either of them is exhausted. The second Iterator is not
+// advanced once the first one has been exhausted.
+func Zip[A, B any](first Iterator[A], second Iterator[B]) Iterator[Pair[A, B]] {
+	return &zipIter[A, B]{
+		first:  first,
+		second: second,
+		done:   false,
+	}
+}
+
+func (it *zipIter[A, B]) Next() Option[Pair[A, B]] {
+	if it.done {
+		return None[Pair[A, B]]()
+	}
+	a := it.first.Next()
+	if a.IsNone() {
+		it.done = true
+		return None[Pair[A, B]]()
+	}
+	b := it.second.Next()
+	if b.IsNone() {
+		it.done = true
+		return None[Pair[A, B]]()
+	}
+	return Some(Pair[A, B]{First: a.Unwrap(), Second: b.Unwrap()})
+}
+
+type zip3Iter[A, B, C any] struct {
+	first  Iterator[A]
+	second Iterator[B]
+	third  Iterator[C]
+	done   bool
+}
+
+// Zip3 returns an Iterator adapter that yields triples of elements from three
+// iterators until any of them is exhausted. Iterators following an exhausted
+// one are not advanced.
+func Zip3[A, B, C any](first Iterator[A], second Iterator[B], third Iterator[C]) Iterator[Triple[A, B, C]] {
+	return &zip3Iter[A, B, C]{
+		first:  first,
+		second: second,
+		third:  third,
+		done:   false,
+	}
+}
+
+func (it *zip3Iter[A, B, C]) Next() Option[Triple[A, B, C]] {
+	if it.done {
+		return None[Triple[A, B, C]]()
+	}
+	a := it.first.Next()
+	if a.IsNone() {
+		it.done = true
+		return None[Triple[A, B, C]]()
+	}
+	b := it.second.Next()
+	if b.IsNone() {
+		it.done = true
+		return None[Triple[A, B, C]]()
+	}
+	c := it.third.Next()
+	if c.IsNone() {
+		it.done = true
+		return None[Triple[A, B, C]]()
+	}
+	return Some(Triple[A, B, C]{First: a.Unwrap(), Second: b.Unwrap(), Third: c.Unwrap()})
+}
+
+type zipLongestIter[A, B any] struct {
+	first  Iterator[A]
+	second Iterator[B]
+	fillA  A
+	fillB  B
+}
+
+// ZipLongest returns an Iterator adapter that yields pairs of elements from two
+// iterators until both of them are exhausted. Elements missing from the shorter
+// Iterator are substituted with fillA or fillB. An Iterator is not advanced
+// again once it has yielded None.
+func ZipLongest[A, B any](first Iterator[A], second Iterator[B], fillA A, fillB B) Iterator[Pair[A, B]] {
+	return &zipLongestIter[A, B]{
+		first:  Fuse(first),
+		second: Fuse(second),
+		fillA:  fillA,
+		fillB:  fillB,
+	}
+}
+
+func (it *zipLongestIter[A, B]) Next() Option[Pair[A, B]] {
+	a := it.first.Next()
+	b := it.second.Next()
+	if a.IsNone() && b.IsNone() {
+		return None[Pair[A, B]]()
+	}
+	return Some(Pair[A, B]{First: a.UnwrapOr(it.fillA), Second: b.UnwrapOr(it.fillB)})
+}
+
+type zipWithIter[A, B, R any] struct {
+	first  func() (A, bool)
+	second func() (B, bool)
+	fn     func(A, B) R
+	done   bool
+}
+
+// ZipWith returns an Iterator adapter that combines the elements of two
+// iterators using fn until either of them is exhausted. The second Iterator is
+// not advanced once the first one has been exhausted.
+func ZipWith[A, B, R any](first Iterator[A], second Iterator[B], fn func(A, B) R) Iterator[R] {
+	return &zipWithIter[A, B, R]{
+		first:  pullFrom(first),
+		second: pullFrom(second),
+		fn:     fn,
+		done:   false,
+	}
+}
+
+func (it *zipWithIter[A, B, R]) Next() Option[R] {
+	v, ok := it.pull()
+	if !ok {
+		return None[R]()
+	}
+	return Some(v)
+}
+
+func (it *zipWithIter[A, B, R]) pull() (R, bool) {
+	var zero R
+	if it.done {
+		return zero, false
+	}
+	a, ok := it.first()
+	if !ok {
+		it.done = true
+		return zero, false
+	}
+	b, ok := it.second()
+	if !ok {
+		it.done = true
+		return zero, false
+	}
+	return it.fn(a, b), true
+}
+
+// Unzip consumes an Iterator of pairs returning the first and the second
+// elements of the pairs as two slices of equal length.
+func Unzip[A, B any](it Iterator[Pair[A, B]]) ([]A, []B) {
+	first, second := []A{}, []B{}
+	ForEach(it, func(p Pair[A, B]) {
+		first = append(first, p.First)
+		second = append(second, p.Second)
+	})
+	return first, second
+}
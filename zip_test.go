@@ -0,0 +1,88 @@
+package iter_test
+
+import (
+	"testing"
+
+	. "github.com/Soft/iter"
+	"github.com/Soft/iter/itertest"
+)
+
+func TestZip(t *testing.T) {
+	itertest.AssertYields(
+		t,
+		Zip(Slice([]int{1, 2}), Slice([]string{"a", "b"})),
+		[]Pair[int, string]{{1, "a"}, {2, "b"}},
+	)
+	names := itertest.Counting(Slice([]string{"a", "b", "c"}))
+	it := Zip[int, string](Slice([]int{1, 2}), names)
+	itertest.AssertYields(t, it, []Pair[int, string]{{1, "a"}, {2, "b"}})
+	itertest.AssertFused(t, it)
+	equals(t, names.Calls(), uint(2))
+	itertest.AssertYields(
+		t,
+		Zip(Slice([]int{1, 2, 3}), Slice([]string{"a"})),
+		[]Pair[int, string]{{1, "a"}},
+	)
+	itertest.AssertYields(t, Zip(Empty[int](), Repeat("a")), nil)
+}
+
+func TestZip3(t *testing.T) {
+	itertest.AssertYields(
+		t,
+		Zip3(Range(0, 3, 1), Slice([]float64{0.5, 1.5, 2.5}), Slice([]string{"a", "b", "c"})),
+		[]Triple[int, float64, string]{{0, 0.5, "a"}, {1, 1.5, "b"}, {2, 2.5, "c"}},
+	)
+	itertest.AssertYields(
+		t,
+		Zip3(Range(0, 3, 1), Repeat(true), Slice([]string{"a", "b"})),
+		[]Triple[int, bool, string]{{0, true, "a"}, {1, true, "b"}},
+	)
+	third := itertest.Counting(Repeat("a"))
+	it := Zip3[int, int, string](Range(0, 5, 1), Range(0, 1, 1), third)
+	itertest.AssertYields(t, it, []Triple[int, int, string]{{0, 0, "a"}})
+	itertest.AssertFused(t, it)
+	equals(t, third.Calls(), uint(1))
+}
+
+func TestZipLongest(t *testing.T) {
+	itertest.AssertYields(
+		t,
+		ZipLongest(Slice([]int{1, 2, 3}), Slice([]string{"a"}), 0, "-"),
+		[]Pair[int, string]{{1, "a"}, {2, "-"}, {3, "-"}},
+	)
+	itertest.AssertYields(
+		t,
+		ZipLongest(Empty[int](), Slice([]string{"a", "b"}), 0, "-"),
+		[]Pair[int, string]{{0, "a"}, {0, "b"}},
+	)
+	itertest.AssertYields(t, ZipLongest(Empty[int](), Empty[string](), 0, "-"), nil)
+	first := itertest.Counting(resurrecting())
+	it := ZipLongest[int, int](first, Range(0, 3, 1), -1, -1)
+	itertest.AssertYields(t, it, []Pair[int, int]{{1, 0}, {-1, 1}, {-1, 2}})
+	itertest.AssertFused(t, it)
+	equals(t, first.Calls(), uint(2))
+}
+
+func TestZipWith(t *testing.T) {
+	add := func(a, b int) int {
+		return a + b
+	}
+	itertest.AssertYields(t, ZipWith(Range(0, 5, 1), Range(10, 13, 1), add), []int{10, 12, 14})
+	itertest.AssertYields(t, ZipWith(Empty[int](), Range(10, 13, 1), add), nil)
+	second := itertest.Counting(Range(0, 10, 1))
+	it := ZipWith[int, int](Range(0, 2, 1), second, add)
+	itertest.AssertYields(t, it, []int{0, 2})
+	itertest.AssertFused(t, it)
+	equals(t, second.Calls(), uint(2))
+}
+
+func TestUnzip(t *testing.T) {
+	first, second := Unzip(Filter(Zip(Range(0, 5, 1), String("abcde")), func(p Pair[int, rune]) bool {
+		return p.First%2 == 0
+	}))
+	equals(t, first, []int{0, 2, 4})
+	equals(t, second, []rune{'a', 'c', 'e'})
+	first, second = Unzip(Empty[Pair[int, rune]]())
+	equals(t, first, []int{})
+	equals(t, second, []rune{})
+}